@@ -0,0 +1,248 @@
+// Command surreal-eventgen generates the dispatcher boilerplate for a package's events.
+//
+// Event structs are annotated with a //surreal:event line at the end of their doc comment:
+//
+//	// WindowResizedEvent is called when the window is resized
+//	//
+//	//surreal:event
+//	type WindowResizedEvent struct { ... }
+//
+// For every annotated event it generates a listener interface, a binding on the dispatcher and a registry entry.
+// The annotation takes optional space separated options:
+//
+//	listener=Name  name of the listener interface, defaults to the event name with Event replaced by Listener
+//	method=Name    name of the listener method, defaults to On followed by the event name without Event
+//	noarg          the listener method takes no arguments
+//	err            also generate an ErrListener variant whose method returns an error
+//	name=Name      name the event is registered under, defaults to package.EventName
+//
+// Usage, from a go:generate directive in the package:
+//
+//	//go:generate go run github.com/gjh33/SurrealEngine/cmd/surreal-eventgen -test -dispatcher WindowEventsDispatcher
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const annotation = "//surreal:event"
+
+// eventDecl is an annotated event struct
+type eventDecl struct {
+	Name     string // The event struct
+	Listener string // The listener interface
+	Method   string // The listener method
+	NoArg    bool   // Whether the listener method takes no arguments
+	Err      bool   // Whether to generate an error returning listener as well
+	Register string // Name in the event registry
+}
+
+// ErrListener is the name of the error returning listener interface
+func (decl eventDecl) ErrListener() string {
+	return strings.TrimSuffix(decl.Listener, "Listener") + "ErrListener"
+}
+
+// generator holds everything the templates need
+type generator struct {
+	Package    string
+	Dispatcher string
+	Doc        string
+	Bindings   string
+	Events     []eventDecl
+}
+
+// HasErr reports whether any event generates an ErrListener
+func (gen *generator) HasErr() bool {
+	for _, event := range gen.Events {
+		if event.Err {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	dispatcher := flag.String("dispatcher", "", "name of the dispatcher struct to generate (required)")
+	doc := flag.String("doc", "", "doc comment for the dispatcher, defaults to a generic description")
+	bindings := flag.String("bindings", "", "name of the bindings variable, defaults to the dispatcher's prefix followed by Bindings")
+	output := flag.String("o", "", "output file, defaults to the snake cased dispatcher name with a _gen.go suffix")
+	dir := flag.String("dir", ".", "directory of the package to read")
+	withTest := flag.Bool("test", false, "also generate a test asserting every event is routable")
+	flag.Parse()
+
+	if *dispatcher == "" {
+		fail(fmt.Errorf("-dispatcher is required"))
+	}
+	if *bindings == "" {
+		*bindings = lowerFirst(strings.TrimSuffix(strings.TrimSuffix(*dispatcher, "Dispatcher"), "Events")) + "Bindings"
+	}
+	if *output == "" {
+		*output = snakeCase(*dispatcher) + "_gen.go"
+	}
+
+	gen, err := parsePackage(*dir, filepath.Base(*output))
+	if err != nil {
+		fail(err)
+	}
+	if len(gen.Events) == 0 {
+		fail(fmt.Errorf("no %s annotations found in %s", annotation, *dir))
+	}
+	gen.Dispatcher = *dispatcher
+	gen.Bindings = *bindings
+	gen.Doc = *doc
+	if gen.Doc == "" {
+		gen.Doc = fmt.Sprintf("%s is a event.Dispatcher that sends out blocking events (processed immediately) for package %s.", *dispatcher, gen.Package)
+	}
+
+	if err := gen.write(dispatcherTemplate, filepath.Join(*dir, *output)); err != nil {
+		fail(err)
+	}
+	if *withTest {
+		if err := gen.write(testTemplate, filepath.Join(*dir, strings.TrimSuffix(*output, ".go")+"_test.go")); err != nil {
+			fail(err)
+		}
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "surreal-eventgen: %s\n", err.Error())
+	os.Exit(1)
+}
+
+// parsePackage collects the annotated events of the package in dir, skipping the generated file itself
+func parsePackage(dir string, generated string) (*generator, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return info.Name() != generated && !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected exactly one package in %s, found %d", dir, len(pkgs))
+	}
+
+	gen := &generator{}
+	for name, pkg := range pkgs {
+		gen.Package = name
+		// Keep declaration order so the generated code reads like the source, files are a map so sort them first
+		filenames := make([]string, 0, len(pkg.Files))
+		for filename := range pkg.Files {
+			filenames = append(filenames, filename)
+		}
+		sort.Strings(filenames)
+		for _, filename := range filenames {
+			file := pkg.Files[filename]
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if _, ok := typeSpec.Type.(*ast.StructType); !ok {
+						continue
+					}
+					doc := typeSpec.Doc
+					if doc == nil {
+						doc = genDecl.Doc
+					}
+					event, annotated, err := parseAnnotation(name, typeSpec.Name.Name, doc)
+					if err != nil {
+						return nil, fmt.Errorf("%s: %s", fset.Position(typeSpec.Pos()), err.Error())
+					}
+					if annotated {
+						gen.Events = append(gen.Events, event)
+					} else if strings.HasSuffix(typeSpec.Name.Name, "Event") && !strings.HasPrefix(typeSpec.Name.Name, "Base") && typeSpec.Name.IsExported() {
+						fmt.Fprintf(os.Stderr, "surreal-eventgen: warning: %s is not annotated with %s and won't be routed\n", typeSpec.Name.Name, annotation)
+					}
+				}
+			}
+		}
+	}
+	return gen, nil
+}
+
+// parseAnnotation reads the annotation off an event's doc comment
+func parseAnnotation(pkg string, name string, doc *ast.CommentGroup) (eventDecl, bool, error) {
+	event := eventDecl{
+		Name:     name,
+		Listener: strings.TrimSuffix(name, "Event") + "Listener",
+		Method:   "On" + strings.TrimSuffix(name, "Event"),
+		Register: pkg + "." + name,
+	}
+	if doc == nil {
+		return event, false, nil
+	}
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, annotation) {
+			continue
+		}
+		for _, option := range strings.Fields(strings.TrimPrefix(comment.Text, annotation)) {
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "listener":
+				event.Listener = value
+			case "method":
+				event.Method = value
+			case "name":
+				event.Register = value
+			case "noarg":
+				event.NoArg = true
+			case "err":
+				event.Err = true
+			default:
+				return event, false, fmt.Errorf("unknown %s option \"%s\"", annotation, option)
+			}
+		}
+		return event, true, nil
+	}
+	return event, false, nil
+}
+
+func (gen *generator) write(tmpl *template.Template, path string) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, gen); err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("generated invalid code for %s.\n Format Error: %s", path, err.Error())
+	}
+	return os.WriteFile(path, source, 0644)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+func snakeCase(s string) string {
+	var out []rune
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				out = append(out, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	// comment turns text into a // comment, line by line
+	"comment": func(text string) string {
+		return "// " + strings.ReplaceAll(text, "\n", "\n// ")
+	},
+}
+
+var dispatcherTemplate = template.Must(template.New("dispatcher").Funcs(funcs).Parse(`// Code generated by surreal-eventgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/gjh33/SurrealEngine/core/event"
+
+{{comment .Doc}}
+type {{.Dispatcher}} struct {
+	bus event.Bus
+}
+
+// {{.Bindings}} adapts the listener interfaces onto the bus
+var {{.Bindings}} = event.Bindings{
+{{- range .Events}}
+{{- if .NoArg}}
+	event.Listener(func(l {{.Listener}}, _ {{.Name}}) { l.{{.Method}}() }),
+{{- else}}
+	event.Listener({{.Listener}}.{{.Method}}),
+{{- end}}
+{{- end}}
+{{- range .Events}}
+{{- if .Err}}
+{{- if .NoArg}}
+	event.ListenerErr(func(l {{.ErrListener}}, _ {{.Name}}) error { return l.{{.Method}}() }),
+{{- else}}
+	event.ListenerErr({{.ErrListener}}.{{.Method}}),
+{{- end}}
+{{- end}}
+{{- end}}
+}
+
+// RegisterEvents adds every event of the dispatcher to a registry, so they can be recorded, replayed and bridged
+func RegisterEvents(registry *event.Registry) {
+{{- range .Events}}
+	event.Register[{{.Name}}](registry, "{{.Register}}")
+{{- end}}
+}
+
+// Bus returns the underlying event bus, for use with event.Subscribe and event.Publish
+func (dispatcher *{{.Dispatcher}}) Bus() *event.Bus {
+	return &dispatcher.bus
+}
+
+// Subscribe implements the event.Dispatcher interface
+func (dispatcher *{{.Dispatcher}}) Subscribe(subscriber event.Subscriber) (*event.Subscription, error) {
+	return {{.Bindings}}.Bind(&dispatcher.bus, subscriber)
+}
+
+// Dispatch implements the event.Dispatcher interface
+func (dispatcher *{{.Dispatcher}}) Dispatch(e event.Event) (bool, error) {
+	switch e.(type) {
+	case {{range $i, $event := .Events}}{{if $i}}, {{end}}{{$event.Name}}{{end}}:
+		return dispatcher.bus.Dispatch(e)
+	}
+	return false, &event.UnknownEventError{}
+}
+{{range .Events}}
+// {{.Listener}} defines the subscriber interface for {{.Name}}
+type {{.Listener}} interface {
+	{{.Method}}({{if not .NoArg}}e {{.Name}}{{end}})
+}
+{{if .Err}}
+// {{.ErrListener}} is {{.Listener}} for listeners that can fail. Errors are returned from Dispatch
+type {{.ErrListener}} interface {
+	{{.Method}}({{if not .NoArg}}e {{.Name}}{{end}}) error
+}
+{{end}}
+{{- end}}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`// Code generated by surreal-eventgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .HasErr}}
+	"errors"
+{{- end}}
+	"testing"
+)
+
+// routingProbe implements every listener interface of {{.Dispatcher}}, counting its calls
+type routingProbe struct {
+	calls map[string]int
+}
+{{range .Events}}
+func (probe *routingProbe) {{.Method}}({{if not .NoArg}}e {{.Name}}{{end}}) {
+	probe.calls["{{.Name}}"]++
+}
+{{end}}
+func Test{{.Dispatcher}}Routing(t *testing.T) {
+	var dispatcher {{.Dispatcher}}
+	probe := &routingProbe{calls: make(map[string]int)}
+	if _, err := dispatcher.Subscribe(probe); err != nil {
+		t.Fatalf("subscribing the probe failed: %s", err.Error())
+	}
+{{range .Events}}
+	if _, err := dispatcher.Dispatch({{.Name}}{}); err != nil {
+		t.Errorf("dispatching {{.Name}} failed: %s", err.Error())
+	}
+	if probe.calls["{{.Name}}"] != 1 {
+		t.Errorf("{{.Name}} was not routed to {{.Listener}}.{{.Method}}")
+	}
+{{end -}}
+}
+{{- if .HasErr}}
+
+// errRouting is returned by every errRoutingProbe method, so the tests can check it comes back from Dispatch
+var errRouting = errors.New("routing probe")
+
+// errRoutingProbe implements every error returning listener interface of {{.Dispatcher}}, counting its calls
+type errRoutingProbe struct {
+	calls map[string]int
+}
+{{range .Events}}{{if .Err}}
+func (probe *errRoutingProbe) {{.Method}}({{if not .NoArg}}e {{.Name}}{{end}}) error {
+	probe.calls["{{.Name}}"]++
+	return errRouting
+}
+{{end}}{{end}}
+func Test{{.Dispatcher}}ErrRouting(t *testing.T) {
+	var dispatcher {{.Dispatcher}}
+	probe := &errRoutingProbe{calls: make(map[string]int)}
+	if _, err := dispatcher.Subscribe(probe); err != nil {
+		t.Fatalf("subscribing the probe failed: %s", err.Error())
+	}
+{{range .Events}}{{if .Err}}
+	if _, err := dispatcher.Dispatch({{.Name}}{}); !errors.Is(err, errRouting) {
+		t.Errorf("dispatching {{.Name}} returned %v, expected the probe's error", err)
+	}
+	if probe.calls["{{.Name}}"] != 1 {
+		t.Errorf("{{.Name}} was not routed to {{.ErrListener}}.{{.Method}}")
+	}
+{{end}}{{end -}}
+}
+{{- end}}
+`))
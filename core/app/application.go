@@ -1,28 +1,65 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/core/mainthread"
 	"github.com/gjh33/SurrealEngine/graphics/win"
 
 	gfx "github.com/gjh33/SurrealEngine/graphics"
-
-	"github.com/vulkan-go/glfw/v3.3/glfw"
 )
 
 // Application represents top most information about a Surreal Application
 type Application struct {
-	Name     string          // The name of the application
-	Version  SemanticVersion // The version of the application
-	Contexts []gfx.Context   // Contexts being rendered to
+	Name       string          // The name of the application
+	Version    SemanticVersion // The version of the application
+	Contexts   []gfx.Context   // Contexts being rendered to. Managed by the graphics module, see GraphicsModule
+	Events     *event.Queue    // Events that can be posted from any goroutine. They are dispatched at the start of every frame
+	OnError    func(error)     // Called with any errors or recovered panics from listeners. Logs them by default
+	Recorder   *event.Recorder // When set, every application and window event is recorded
+	Replay     *event.Player   // When set, a recording is replayed into the application, frame by frame
+	Time       *Time           // Frame timing. Swap its Clock for a ManualClock to step time in tests
+	Options    Options         // How the application runs
+	Services   *Services       // App-wide services, see Provide and Get. Make scopes from it for shorter lived ones
+	Config     *Config         // Where Settings are loaded from on Run
+	Settings   Settings        // Window and graphics settings. Set defaults before Run, Config layers on top of them
+	Migrations *Migrations     // Upgrades data saved by older versions, see Save and Load
+	Keyboard   *win.Keyboard   // Keyboard state of the application's window, updated every frame before ApplicationUpdateEvent
+	Mouse      *win.Mouse      // Mouse state of the application's window, updated every frame before ApplicationUpdateEvent
 
 	ApplicationEventsDispatcher // Application is an event dispatcher
+
+	modules       []Module // Registered with Use
+	running       []Module // Initialized this run, in order
+	state         stateMachine
+	quitRequested atomic.Bool
+	exitCode      atomic.Int32
 }
 
 // New is the default constructor for an Application
 func New(name string, version string) (obj *Application) {
 	obj = new(Application)
 	obj.Name = name
+	obj.Events = event.NewQueue(obj)
+	obj.Time = NewTime(SystemClock{})
+	obj.Services = new(Services)
+	obj.Config = NewConfig(name)
+	obj.Settings = DefaultSettings(name)
+	obj.Migrations = new(Migrations)
+	obj.Keyboard = new(win.Keyboard)
+	obj.Mouse = new(win.Mouse)
+	Provide(obj, obj)
+	obj.OnError = func(err error) { log.Printf("surreal: %s", err.Error()) }
+	obj.Bus().RecoverPanics = true
 	var err error
 	obj.Version, err = ParseVersion(version)
 	if err != nil {
@@ -31,37 +68,253 @@ func New(name string, version string) (obj *Application) {
 	return
 }
 
-// Start is the main entry point for Surreal Applications
-func (application *Application) Start() {
+// Start is the main entry point for Surreal Applications. Call it from main, windowing only works on the main thread
+// It runs the application until it quits, turning SIGINT and SIGTERM into a clean quit. See Run and ExitCode.
+func (application *Application) Start() error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer close(signals)
+	defer signal.Stop(signals)
+	go func() {
+		for sig := range signals {
+			code := 1
+			if number, ok := sig.(syscall.Signal); ok {
+				code = 128 + int(number) // Shell convention for being ended by a signal
+			}
+			application.QuitWithCode(code)
+		}
+	}()
+	return application.Run(context.Background())
+}
+
+// Run runs the application's whole life cycle: startup, module initialization, the update loop, quit, module shutdown,
+// closing the services built from constructors and clean up.
+// It returns once the window closes, Quit is called, Options.ExitWhen is met or ctx is done.
+// Quit and clean up events are always sent once startup was, even when initialization fails.
+func (application *Application) Run(ctx context.Context) (err error) {
+	if err := application.transition(StateStarting); err != nil {
+		return err
+	}
+	application.quitRequested.Store(false)
+	application.exitCode.Store(0)
+	// Windowing calls have to happen on the main thread, which Run is expected to be called from
+	defer mainthread.Bind()()
+
 	// TODO: Remove this code as it is test
 	test := &listenerTester{}
-	_ = application.Subscribe(test)
-	_ = application.Dispatch(ApplicationStartupEvent{})
-	context := &gfx.VulkanContext{}
-	if err := context.Initialize(); err != nil {
-		panic(err.Error())
-	}
-	window := context.Window()
-	_ = window.SetResizable(true)
-	_ = window.SetDecorated(true)
-	if err := window.Create(); err != nil {
-		panic(err.Error())
-	}
-	_ = window.Subscribe(test)
+	_, _ = application.Subscribe(test)
+	if application.Recorder != nil {
+		defer application.Recorder.Attach(application.Bus()).Cancel()
+	}
+	application.dispatch(ApplicationStartupEvent{})
+	defer func() {
+		application.report(application.Events.Flush())
+		application.report(application.transition(StateQuitting))
+		application.dispatch(ApplicationQuitEvent{})
+		err = errors.Join(err, application.shutdownModules())
+		err = errors.Join(err, application.Services.Close())
+		application.dispatch(ApplicationCleanedUpEvent{})
+		application.report(application.transition(StateStopped))
+		if err != nil && application.exitCode.Load() == 0 {
+			application.exitCode.Store(1)
+		}
+	}()
+
+	if application.Config != nil {
+		if err := application.Config.Load(&application.Settings); err != nil {
+			return err
+		}
+	}
+	if err := application.initModules(); err != nil {
+		return err
+	}
+	window := application.Window()
+	if window == nil {
+		return fmt.Errorf("no window to run in, the %s module did not set up a graphics context", GraphicsModuleName)
+	}
+	_, _ = window.Subscribe(test)
 	// End of test
 
-	_ = application.Dispatch(ApplicationInitializedEvent{})
-	for !window.ShouldClose() {
-		_ = application.Dispatch(ApplicationUpdateEvent{})
+	// Window callbacks fire inside glfw.PollEvents, queue them so listeners run at the start of the frame instead
+	if source, ok := window.(interface{ Bus() *event.Bus }); ok {
+		source.Bus().RecoverPanics = true
+		source.Bus().Defer(application.Events)
+		if application.Recorder != nil {
+			defer application.Recorder.Attach(source.Bus()).Cancel()
+		}
+	}
+	replay := &replayTarget{application, window}
+	for _, device := range []event.Subscriber{application.Keyboard, application.Mouse} {
+		if subscription, err := window.Subscribe(device); err == nil {
+			defer subscription.Cancel()
+		}
+	}
+	if subscription, err := window.Subscribe(&pauseOnIconify{application: application}); err == nil {
+		defer subscription.Cancel()
+	}
+
+	application.dispatch(ApplicationInitializedEvent{})
+	if err := application.transition(StateRunning); err != nil {
+		return err
+	}
+	for window.IsCreated() && !application.quitRequested.Load() {
+		fixedSteps := application.Time.Tick()
+		frame := application.Time.FrameCount()
+		if application.Recorder != nil {
+			application.Recorder.SetFrame(frame)
+		}
+		if application.Replay != nil {
+			application.report(application.Replay.Step(frame, replay))
+		}
+		application.report(application.Events.Flush())
+		mainthread.Drain()
+		if application.State() == StatePaused {
+			// Time keeps running while paused, only the updates stop
+			if err := window.PollEvents(); err != nil {
+				return fmt.Errorf("failed to poll window events.\n PollEvents Error: %s", err.Error())
+			}
+			time.Sleep(pausedPollInterval)
+			if err := application.checkExit(ctx, window); err != nil {
+				return err
+			}
+			continue
+		}
+		application.Keyboard.Update()
+		application.Mouse.Update()
+		for i := 0; i < fixedSteps; i++ {
+			step := application.Time.FixedCount() - uint64(fixedSteps-1-i)
+			application.dispatch(ApplicationFixedUpdateEvent{Delta: application.Time.FixedStep, Step: step})
+		}
+		application.dispatch(ApplicationUpdateEvent{Delta: application.Time.Delta(), Frame: frame})
 
 		// TODO: remove all below into main pipeline
-		glfw.PollEvents()
+		if err := window.PollEvents(); err != nil {
+			return fmt.Errorf("failed to poll window events.\n PollEvents Error: %s", err.Error())
+		}
+
+		if err := application.checkExit(ctx, window); err != nil {
+			return err
+		}
+	}
+
+	// Quitting is not up for debate, but the window still gets a chance to close gracefully
+	if window.IsCreated() {
+		if err := window.Close(); err != nil {
+			return fmt.Errorf("failed to close window.\n Close Error: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// checkExit quits once ctx is done or ExitWhen says so, and closes the window once the user asked it to.
+// Checked at the end of every frame, paused or not
+func (application *Application) checkExit(ctx context.Context, window win.Window) error {
+	if ctx.Err() != nil || (application.Options.ExitWhen != nil && application.Options.ExitWhen(application)) {
+		application.Quit()
 	}
-	if err := window.Close(); err != nil {
-		panic(err.Error())
+	// Closing can be cancelled by listeners, in which case we keep running
+	if window.ShouldClose() {
+		if err := window.Close(); err != nil {
+			return fmt.Errorf("failed to close window.\n Close Error: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// Window returns the window of the first graphics context, or nil if there is none
+func (application *Application) Window() win.Window {
+	if len(application.Contexts) == 0 {
+		return nil
+	}
+	return application.Contexts[0].Window()
+}
+
+// State returns the application's current life cycle state. Safe to call from any goroutine.
+func (application *Application) State() State {
+	return application.state.get()
+}
+
+// WaitFor blocks until the application enters state, returning immediately if it is already in it.
+// It returns a StateUnreachableError if the application stops first, or the context's error if ctx is done first.
+// Safe to call from any goroutine, though calling it from a listener on the main thread would block forever.
+func (application *Application) WaitFor(ctx context.Context, state State) error {
+	return application.state.wait(ctx, state)
+}
+
+// Pause stops update events from being sent until Resume is called. Windows and queued events are still processed.
+// Returns an InvalidTransitionError unless the application is running. Call it from the main thread, i.e. a listener
+func (application *Application) Pause() error {
+	return application.transition(StatePaused)
+}
+
+// Resume restarts update events after Pause. Returns an InvalidTransitionError unless the application is paused.
+// Call it from the main thread, i.e. a listener
+func (application *Application) Resume() error {
+	return application.transition(StateRunning)
+}
+
+// transition moves the application to the next state, sending out an ApplicationStateChangedEvent
+func (application *Application) transition(next State) error {
+	previous, err := application.state.set(next)
+	if err != nil {
+		return err
+	}
+	application.dispatch(ApplicationStateChangedEvent{Old: previous, New: next})
+	return nil
+}
+
+// Quit asks the application to quit at the end of the current frame. Safe to call from any goroutine.
+func (application *Application) Quit() {
+	application.quitRequested.Store(true)
+}
+
+// QuitWithCode is Quit, also setting the exit code the process should end with
+func (application *Application) QuitWithCode(code int) {
+	application.exitCode.Store(int32(code))
+	application.Quit()
+}
+
+// ExitCode returns the code the process should exit with once Run returns, i.e. os.Exit(application.ExitCode())
+// It is 0 unless set by QuitWithCode, a signal, or Run failing.
+func (application *Application) ExitCode() int {
+	return int(application.exitCode.Load())
+}
+
+// dispatch sends out an application event, reporting anything that went wrong in its listeners
+func (application *Application) dispatch(e event.Event) {
+	_, err := application.Dispatch(e)
+	application.report(err)
+}
+
+// report forwards an error to OnError
+func (application *Application) report(err error) {
+	if err != nil && application.OnError != nil {
+		application.OnError(err)
+	}
+}
+
+// pausedPollInterval is how long the run loop sleeps between polling the window while paused, so it doesn't spin
+const pausedPollInterval = 10 * time.Millisecond
+
+// pauseOnIconify pauses the application while its window is iconified
+type pauseOnIconify struct {
+	application *Application
+	paused      bool // Only resume what we paused, an application paused by the user stays paused
+}
+
+// OnWindowIconified implements the win.WindowIconifiedListener interface
+func (listener *pauseOnIconify) OnWindowIconified(e win.WindowIconifiedEvent) {
+	if listener.application.State() == StateRunning {
+		listener.paused = listener.application.Pause() == nil
+	}
+}
+
+// OnWindowRestored implements the win.WindowRestoredListener interface
+func (listener *pauseOnIconify) OnWindowRestored(e win.WindowRestoredEvent) {
+	if listener.paused {
+		listener.paused = false
+		listener.application.report(listener.application.Resume())
 	}
-	_ = application.Dispatch(ApplicationQuitEvent{})
-	_ = application.Dispatch(ApplicationCleanedUpEvent{})
 }
 
 type listenerTester struct {
@@ -0,0 +1,52 @@
+package app
+
+import "time"
+
+//go:generate go run github.com/gjh33/SurrealEngine/cmd/surreal-eventgen -test -dispatcher ApplicationEventsDispatcher -o application_events_gen.go -doc "ApplicationEventsDispatcher is a event.Dispatcher that sends out blocking events (processed immediately) for application life cycle."
+
+// ApplicationStartupEvent is the event called right after the application is started, before any initialization.
+// Things like graphics libraries etc will not yet be initialized
+//
+//surreal:event noarg err
+type ApplicationStartupEvent struct{}
+
+// ApplicationInitializedEvent is the event called after initialization. It is called just before the first update loop.
+// graphics libraries will be initialized
+//
+//surreal:event noarg err
+type ApplicationInitializedEvent struct{}
+
+// ApplicationFixedUpdateEvent is the event called at a fixed rate, set by Application.Time.FixedStep, before ApplicationUpdateEvent.
+// It may be called several times in one frame, or not at all. Use it for physics and anything else that needs a stable step
+//
+//surreal:event err
+type ApplicationFixedUpdateEvent struct {
+	Delta time.Duration // The time simulated by this update
+	Step  uint64        // Number of this fixed update, counted from 1
+}
+
+// ApplicationUpdateEvent is the event called continuously in the run loop, once per frame. The same timing is available from Application.Time
+//
+//surreal:event err
+type ApplicationUpdateEvent struct {
+	Delta time.Duration // Scaled time the last frame took
+	Frame uint64        // Number of this frame, counted from 1
+}
+
+// ApplicationStateChangedEvent is the event called whenever the application moves to a new State, see Application.State
+//
+//surreal:event err
+type ApplicationStateChangedEvent struct {
+	Old State // The state the application left
+	New State // The state the application is now in
+}
+
+// ApplicationQuitEvent is the event called when quitting the application before cleanup. It is called immediately after exiting the game loop
+//
+//surreal:event noarg err
+type ApplicationQuitEvent struct{}
+
+// ApplicationCleanedUpEvent is the event called after the application is cleaned up after quitting. It is called just before ending the main thread.
+//
+//surreal:event noarg err
+type ApplicationCleanedUpEvent struct{}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+func TestDispatchUnknownEvent(t *testing.T) {
+	var dispatcher ApplicationEventsDispatcher
+	var unknown *event.UnknownEventError
+	if _, err := dispatcher.Dispatch(struct{}{}); !errors.As(err, &unknown) {
+		t.Errorf("dispatching a foreign event returned %v, want an UnknownEventError", err)
+	}
+	if _, err := dispatcher.Dispatch(ApplicationUpdateEvent{}); err != nil {
+		t.Errorf("dispatching an event without subscribers returned %v", err)
+	}
+}
+
+// testContext is a graphics context bound to a window of the test's choosing
+type testContext struct {
+	window win.Window
+}
+
+func (cxt *testContext) Initialize() error   { return cxt.window.Initialize() }
+func (cxt *testContext) Window() win.Window  { return cxt.window }
+func (cxt *testContext) IsInitialized() bool { return cxt.window.IsInitialized() }
+
+// closingWindow is a NullWindow whose close button can be clicked
+type closingWindow struct {
+	win.NullWindow
+	closeRequested bool
+}
+
+func (window *closingWindow) ShouldClose() bool {
+	return window.closeRequested
+}
+
+// runPaused runs an application on window, which is iconified on the first update so the application pauses.
+// Fails the test if the application has not stopped by itself within a few seconds
+func runPaused(t *testing.T, application *Application, window win.Window, whilePaused func()) {
+	t.Helper()
+	application.Options.Context = &testContext{window}
+	defer event.Subscribe(application.Bus(), func(e ApplicationUpdateEvent) { _ = window.Iconify() }).Cancel()
+	defer event.Subscribe(application.Bus(), func(e ApplicationStateChangedEvent) {
+		if e.New == StatePaused {
+			whilePaused()
+		}
+	}).Cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := application.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("the application only stopped once the context ran out")
+	}
+}
+
+func TestExitWhenWhilePaused(t *testing.T) {
+	application := New("test", "1.0.0")
+	paused := false
+	application.Options.ExitWhen = func(*Application) bool { return paused }
+	runPaused(t, application, new(win.NullWindow), func() { paused = true })
+}
+
+func TestCloseWhilePaused(t *testing.T) {
+	application := New("test", "1.0.0")
+	window := new(closingWindow)
+	runPaused(t, application, window, func() { window.closeRequested = true })
+	if window.IsCreated() {
+		t.Error("the window is still open")
+	}
+}
+
+// unpollableWindow is a NullWindow whose events can't be polled
+type unpollableWindow struct {
+	win.NullWindow
+}
+
+func (window *unpollableWindow) PollEvents() error {
+	return errPoll
+}
+
+var errPoll = errors.New("poll failed")
+
+func TestRunPollEventsError(t *testing.T) {
+	application := New("test", "1.0.0")
+	application.Options.Context = &testContext{new(unpollableWindow)}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := application.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), errPoll.Error()) {
+		t.Errorf("Run returned %v, want the error from PollEvents", err)
+	}
+}
@@ -0,0 +1,621 @@
+package app
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config loads typed settings in layers, each overriding the one before:
+// the target's existing values (defaults), File, the user preferences file, environment variables and finally command line flags.
+//
+// Keys are dotted paths through the target's fields, i.e. "graphics.VSync", named by their json tag or field name and matched ignoring case.
+// Environment variables are EnvPrefix followed by the key with dots as underscores, i.e. GAME_GRAPHICS_VSYNC=1 for the prefix GAME_.
+// Flags are -key=value, --key=value or -key value, and a bare -key for booleans.
+// Variables and flags that don't name a single value, i.e. -verbose or -graphics, are left alone for the program to handle.
+type Config struct {
+	File            string                  // Config file to load, its format picked by extension. Optional, skipped when empty
+	PreferencesFile string                  // File Set preferences are saved to and loaded from. Optional, skipped when empty or missing
+	EnvPrefix       string                  // Prefix of the environment variables to load. No environment variables are loaded when empty
+	Args            []string                // Command line arguments to load flags from. Defaults to os.Args[1:]
+	Formats         map[string]ConfigFormat // File formats by extension, including the dot. NewConfig registers JSON, TOML and YAML
+
+	preferences map[string]interface{}
+}
+
+// ConfigFormat encodes config files. Register one in Config.Formats to support another file type, or a full featured TOML or YAML library
+type ConfigFormat interface {
+	Unmarshal(data []byte) (map[string]interface{}, error)
+	Marshal(values map[string]interface{}) ([]byte, error)
+}
+
+// ConfigValidator is implemented by config structs that check their own values once every layer is loaded.
+// Return a ConfigError to name the offending key, relative to the struct
+type ConfigValidator interface {
+	Validate() error
+}
+
+// ConfigError is the error returned when a config value can't be loaded or fails validation
+type ConfigError struct {
+	Key    string // The offending key, i.e. "graphics.VSync"
+	Source string // Where the value came from, i.e. a file path, "environment" or "flags"
+	Err    error
+}
+
+// Error implements the error interface
+func (err ConfigError) Error() string {
+	if err.Source == "" {
+		return fmt.Sprintf("config key %s: %s", err.Key, err.Err.Error())
+	}
+	return fmt.Sprintf("config key %s (from %s): %s", err.Key, err.Source, err.Err.Error())
+}
+
+// Unwrap returns the underlying error
+func (err ConfigError) Unwrap() error {
+	return err.Err
+}
+
+// errUnknownKey is wrapped by a ConfigError for keys that don't match any field
+var errUnknownKey = errors.New("unknown key")
+
+// NewConfig returns a Config for the named application. Environment variables are prefixed with the upper case name, i.e. MYGAME_,
+// and preferences are kept in the user's config directory
+func NewConfig(name string) *Config {
+	config := &Config{
+		Args: os.Args[1:],
+		Formats: map[string]ConfigFormat{
+			".json": JSONFormat{},
+			".toml": TOMLFormat{},
+			".yaml": YAMLFormat{},
+			".yml":  YAMLFormat{},
+		},
+	}
+	prefix := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if prefix != "" {
+		config.EnvPrefix = prefix + "_"
+	}
+	if dir, err := os.UserConfigDir(); err == nil && name != "" {
+		config.PreferencesFile = filepath.Join(dir, name, "preferences.json")
+	}
+	return config
+}
+
+// Load fills target, a pointer to a struct, from every layer, then validates it
+func (config *Config) Load(target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config target must be a pointer to a struct, not %T", target)
+	}
+	value = value.Elem()
+
+	if config.File != "" {
+		values, err := config.readFile(config.File)
+		if err != nil {
+			return err
+		}
+		if err := assign(value, values, "", config.File); err != nil {
+			return err
+		}
+	}
+	if config.PreferencesFile != "" {
+		values, err := config.readFile(config.PreferencesFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		config.preferences = canonicalKeys(value, values)
+		if err := assign(value, values, "", config.PreferencesFile); err != nil {
+			return err
+		}
+	}
+	if config.EnvPrefix != "" {
+		for _, variable := range os.Environ() {
+			name, raw, _ := strings.Cut(variable, "=")
+			if !strings.HasPrefix(name, config.EnvPrefix) {
+				continue
+			}
+			key := strings.ReplaceAll(strings.TrimPrefix(name, config.EnvPrefix), "_", ".")
+			if field, err := lookup(value, key); err != nil || !isValue(field) {
+				continue // Not ours, i.e. a variable for a library or the program itself
+			}
+			if err := setKey(value, key, raw, "environment"); err != nil {
+				return err
+			}
+		}
+	}
+	if err := config.loadFlags(value); err != nil {
+		return err
+	}
+	return validate(value, "")
+}
+
+// Set changes a key in target and remembers it as a user preference, to be written by SavePreferences
+func (config *Config) Set(target interface{}, key string, value interface{}) error {
+	if err := setKey(reflect.ValueOf(target), key, value, ""); err != nil {
+		return err
+	}
+	if config.preferences == nil {
+		config.preferences = make(map[string]interface{})
+	}
+	values := config.preferences
+	parts := strings.Split(canonicalKey(reflect.ValueOf(target), key), ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := values[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			values[part] = next
+		}
+		values = next
+	}
+	values[parts[len(parts)-1]] = value
+	return nil
+}
+
+// SavePreferences writes every preference loaded or Set to PreferencesFile, so they persist between runs
+func (config *Config) SavePreferences() error {
+	if config.PreferencesFile == "" {
+		return errors.New("no preferences file to save to")
+	}
+	format, err := config.format(config.PreferencesFile)
+	if err != nil {
+		return err
+	}
+	data, err := format.Marshal(config.preferences)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences.\n Marshal Error: %s", err.Error())
+	}
+	if err := os.MkdirAll(filepath.Dir(config.PreferencesFile), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(config.PreferencesFile, data, 0o644)
+}
+
+// format returns the format for a file by its extension
+func (config *Config) format(path string) (ConfigFormat, error) {
+	extension := strings.ToLower(filepath.Ext(path))
+	format, ok := config.Formats[extension]
+	if !ok {
+		return nil, fmt.Errorf("no config format registered for %q files", extension)
+	}
+	return format, nil
+}
+
+// readFile reads and decodes a config file
+func (config *Config) readFile(path string) (map[string]interface{}, error) {
+	format, err := config.format(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := format.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s.\n Unmarshal Error: %s", path, err.Error())
+	}
+	return values, nil
+}
+
+// loadFlags applies every flag in Args that names a key in target
+func (config *Config) loadFlags(target reflect.Value) error {
+	for i := 0; i < len(config.Args); i++ {
+		arg := config.Args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+		key, raw, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		field, err := lookup(target, key)
+		if err != nil || !isValue(field) {
+			continue // Not ours, i.e. a flag for the program itself
+		}
+		if !hasValue {
+			if field.Kind() == reflect.Bool && (i+1 >= len(config.Args) || !isBool(config.Args[i+1])) {
+				raw = "true"
+			} else if i+1 < len(config.Args) {
+				i++
+				raw = config.Args[i]
+			} else {
+				return ConfigError{Key: key, Source: "flags", Err: errors.New("missing value")}
+			}
+		}
+		if err := setKey(target, key, raw, "flags"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// textUnmarshalerType is set from text, even when it is a struct
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// isValue returns whether a field holds a single value, rather than a group of keys that can't be set from one string
+func isValue(field reflect.Value) bool {
+	t := field.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	return t.Kind() != reflect.Struct && t.Kind() != reflect.Map
+}
+
+// isBool returns if a flag argument is a boolean value
+func isBool(arg string) bool {
+	_, err := strconv.ParseBool(arg)
+	return err == nil
+}
+
+// fieldKey returns the key for a struct field: its json tag name, or its name. Empty if the field is skipped
+func fieldKey(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// child returns the field or map element of value named part, ignoring case, and the key it is canonically known by.
+// Fields of embedded structs are promoted, as in Go
+func child(value reflect.Value, part string) (reflect.Value, string, bool) {
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+				if found, key, ok := child(value.Field(i), part); ok {
+					return found, key, true
+				}
+				continue
+			}
+			if key := fieldKey(field); key != "" && strings.EqualFold(key, part) {
+				return value.Field(i), key, true
+			}
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() == reflect.String {
+			return reflect.Value{}, part, true
+		}
+	}
+	return reflect.Value{}, "", false
+}
+
+// canonicalKey returns a dotted key with every part named the way child knows it by, so keys differing only in case are stored once.
+// Parts past the first one that doesn't match are kept as they are
+func canonicalKey(value reflect.Value, key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		for value.Kind() == reflect.Pointer {
+			value = reflect.New(value.Type().Elem()).Elem()
+		}
+		next, name, ok := child(value, part)
+		if !ok {
+			break
+		}
+		if value.Kind() == reflect.Map {
+			next = reflect.New(value.Type().Elem()).Elem()
+		}
+		parts[i], value = name, next
+	}
+	return strings.Join(parts, ".")
+}
+
+// canonicalKeys returns decoded config values with their keys named the way child knows them by, see canonicalKey
+func canonicalKeys(value reflect.Value, values map[string]interface{}) map[string]interface{} {
+	for value.Kind() == reflect.Pointer {
+		value = reflect.New(value.Type().Elem()).Elem()
+	}
+	canonical := make(map[string]interface{}, len(values))
+	for part, raw := range values {
+		next, name, ok := child(value, part)
+		if !ok {
+			canonical[part] = raw
+			continue
+		}
+		if value.Kind() == reflect.Map {
+			next = reflect.New(value.Type().Elem()).Elem()
+		}
+		if nested, ok := raw.(map[string]interface{}); ok {
+			raw = canonicalKeys(next, nested)
+		}
+		canonical[name] = raw
+	}
+	return canonical
+}
+
+// lookup returns the field a dotted key names
+func lookup(value reflect.Value, key string) (reflect.Value, error) {
+	for _, part := range strings.Split(key, ".") {
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				value = reflect.New(value.Type().Elem()).Elem()
+			} else {
+				value = value.Elem()
+			}
+		}
+		next, _, ok := child(value, part)
+		if !ok {
+			return reflect.Value{}, errUnknownKey
+		}
+		if value.Kind() == reflect.Map {
+			next = reflect.New(value.Type().Elem()).Elem()
+		}
+		value = next
+	}
+	return value, nil
+}
+
+// setKey assigns raw to the field a dotted key names. Maps and nil pointers along the way are created as needed
+func setKey(value reflect.Value, key string, raw interface{}, source string) error {
+	parts := strings.Split(key, ".")
+	values := make(map[string]interface{})
+	leaf := values
+	for _, part := range parts[:len(parts)-1] {
+		next := make(map[string]interface{})
+		leaf[part] = next
+		leaf = next
+	}
+	leaf[parts[len(parts)-1]] = raw
+	return assign(value, values, "", source)
+}
+
+// assign sets value from a decoded config value: a map for structs and maps, a slice for slices and arrays,
+// or a string, number or boolean for everything else. Strings are parsed into the field's type
+func assign(value reflect.Value, raw interface{}, key string, source string) error {
+	fail := func(err error) error {
+		return ConfigError{Key: key, Source: source, Err: err}
+	}
+	if value.Kind() == reflect.Pointer {
+		if raw == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return assign(value.Elem(), raw, key, source)
+	}
+	if text, ok := raw.(string); ok && value.CanAddr() {
+		if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := unmarshaler.UnmarshalText([]byte(text)); err != nil {
+				return fail(err)
+			}
+			return nil
+		}
+	}
+
+	switch raw := raw.(type) {
+	case nil:
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	case map[string]interface{}:
+		parts := make([]string, 0, len(raw))
+		for part := range raw {
+			parts = append(parts, part)
+		}
+		sort.Strings(parts)
+		for _, part := range parts {
+			field, name, ok := child(value, part)
+			childKey := joinKey(key, name)
+			if !ok {
+				return ConfigError{Key: joinKey(key, part), Source: source, Err: errUnknownKey}
+			}
+			if value.Kind() == reflect.Map {
+				if value.IsNil() {
+					value.Set(reflect.MakeMap(value.Type()))
+				}
+				element := reflect.New(value.Type().Elem()).Elem()
+				if existing := value.MapIndex(reflect.ValueOf(part).Convert(value.Type().Key())); existing.IsValid() {
+					element.Set(existing)
+				}
+				if err := assign(element, raw[part], childKey, source); err != nil {
+					return err
+				}
+				value.SetMapIndex(reflect.ValueOf(part).Convert(value.Type().Key()), element)
+				continue
+			}
+			if err := assign(field, raw[part], childKey, source); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		switch value.Kind() {
+		case reflect.Slice:
+			value.Set(reflect.MakeSlice(value.Type(), len(raw), len(raw)))
+		case reflect.Array:
+			if len(raw) != value.Len() {
+				return fail(fmt.Errorf("expected %d values, got %d", value.Len(), len(raw)))
+			}
+		default:
+			return fail(fmt.Errorf("expected %s, got a list", value.Type()))
+		}
+		for i, element := range raw {
+			if err := assign(value.Index(i), element, fmt.Sprintf("%s.%d", key, i), source); err != nil {
+				return err
+			}
+		}
+		return nil
+	case string:
+		if err := parseInto(value, raw); err != nil {
+			return fail(err)
+		}
+		return nil
+	case float64:
+		if err := numberInto(value, raw); err != nil {
+			return fail(err)
+		}
+		return nil
+	case bool:
+		if value.Kind() != reflect.Bool {
+			return fail(fmt.Errorf("expected %s, got a boolean", value.Type()))
+		}
+		value.SetBool(raw)
+		return nil
+	}
+	// Values passed to Config.Set directly
+	given := reflect.ValueOf(raw)
+	if !given.Type().AssignableTo(value.Type()) {
+		if given.Type().ConvertibleTo(value.Type()) && given.Kind() == value.Kind() {
+			value.Set(given.Convert(value.Type()))
+			return nil
+		}
+		return fail(fmt.Errorf("expected %s, got %s", value.Type(), given.Type()))
+	}
+	value.Set(given)
+	return nil
+}
+
+// durationType is parsed with time.ParseDuration rather than as a number
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseInto parses a string into a scalar value
+func parseInto(value reflect.Value, text string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(text, 0, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(text, 0, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("%s can not be set from text", value.Type())
+	}
+	return nil
+}
+
+// numberInto sets a numeric value from a decoded number, refusing fractions and overflow for integers
+func numberInto(value reflect.Value, number float64) error {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number != math.Trunc(number) || value.OverflowInt(int64(number)) {
+			return fmt.Errorf("%v does not fit in %s", number, value.Type())
+		}
+		value.SetInt(int64(number))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if number < 0 || number != math.Trunc(number) || value.OverflowUint(uint64(number)) {
+			return fmt.Errorf("%v does not fit in %s", number, value.Type())
+		}
+		value.SetUint(uint64(number))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(number)
+	default:
+		return fmt.Errorf("expected %s, got a number", value.Type())
+	}
+	return nil
+}
+
+// validate runs ConfigValidator on value and every struct inside it, prefixing the keys of any ConfigError with where it was found
+func validate(value reflect.Value, key string) error {
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := fieldKey(field)
+		if name == "" {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			name = "" // Promoted
+		}
+		if err := validate(value.Field(i), joinKey(key, name)); err != nil {
+			return err
+		}
+	}
+	validator, ok := value.Addr().Interface().(ConfigValidator)
+	if !ok {
+		return nil
+	}
+	err := validator.Validate()
+	var configErr ConfigError
+	if errors.As(err, &configErr) {
+		configErr.Key = joinKey(key, configErr.Key)
+		return configErr
+	}
+	if err != nil {
+		return ConfigError{Key: key, Err: err}
+	}
+	return nil
+}
+
+// joinKey appends a part to a dotted key
+func joinKey(key string, part string) string {
+	if key == "" {
+		return part
+	}
+	if part == "" {
+		return key
+	}
+	return key + "." + part
+}
+
+// JSONFormat reads and writes JSON config files
+type JSONFormat struct{}
+
+// Unmarshal implements the ConfigFormat interface
+func (JSONFormat) Unmarshal(data []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	err := json.Unmarshal(data, &values)
+	return values, err
+}
+
+// Marshal implements the ConfigFormat interface
+func (JSONFormat) Marshal(values map[string]interface{}) ([]byte, error) {
+	return json.MarshalIndent(values, "", "  ")
+}
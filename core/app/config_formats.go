@@ -0,0 +1,755 @@
+package app
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TOMLFormat reads and writes TOML config files. It covers what config files use: tables, dotted keys, strings,
+// numbers, booleans, arrays and inline tables. Multi-line strings, dates and arrays of tables are not supported
+type TOMLFormat struct{}
+
+// Unmarshal implements the ConfigFormat interface
+func (TOMLFormat) Unmarshal(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	table := root
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		number := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		// Arrays and inline tables may span lines
+		for bracketDepth(line) > 0 && i+1 < len(lines) {
+			i++
+			line += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+		if line == "" {
+			continue
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "[["):
+			err = errors.New("arrays of tables are not supported")
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				err = errors.New("unterminated table header")
+				break
+			}
+			var keys []string
+			if keys, err = splitTOMLKey(line[1 : len(line)-1]); err == nil {
+				table, err = tomlTable(root, keys)
+			}
+		default:
+			err = setTOMLValue(table, line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", number, err.Error())
+		}
+	}
+	return root, nil
+}
+
+// Marshal implements the ConfigFormat interface
+func (TOMLFormat) Marshal(values map[string]interface{}) ([]byte, error) {
+	plain, err := plainValue(reflect.ValueOf(values))
+	if err != nil {
+		return nil, err
+	}
+	var out strings.Builder
+	if err := writeTOMLTable(&out, nil, plain.(map[string]interface{})); err != nil {
+		return nil, err
+	}
+	return []byte(out.String()), nil
+}
+
+// setTOMLValue parses a key = value line into table
+func setTOMLValue(table map[string]interface{}, line string) error {
+	split := unquotedIndex(line, '=', false)
+	if split < 0 {
+		return fmt.Errorf("expected key = value, got %q", line)
+	}
+	keys, err := splitTOMLKey(line[:split])
+	if err != nil {
+		return err
+	}
+	value, err := parseTOMLValue(strings.TrimSpace(line[split+1:]))
+	if err != nil {
+		return err
+	}
+	parent, err := tomlTable(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	key := keys[len(keys)-1]
+	if _, ok := parent[key]; ok {
+		return fmt.Errorf("key %s is defined twice", key)
+	}
+	parent[key] = value
+	return nil
+}
+
+// tomlTable returns the table keys name under table, creating it if needed
+func tomlTable(table map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	for _, key := range keys {
+		existing, ok := table[key]
+		if !ok {
+			next := make(map[string]interface{})
+			table[key] = next
+			table = next
+			continue
+		}
+		next, ok := existing.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key %s is a value, not a table", key)
+		}
+		table = next
+	}
+	return table, nil
+}
+
+// bareTOMLKey matches keys that don't need quotes
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// splitTOMLKey splits a dotted key into its parts, unquoting quoted ones
+func splitTOMLKey(key string) ([]string, error) {
+	var parts []string
+	for {
+		split := unquotedIndex(key, '.', false)
+		part := key
+		if split >= 0 {
+			part = key[:split]
+		}
+		part = strings.TrimSpace(part)
+		switch {
+		case strings.HasPrefix(part, `"`):
+			unquoted, err := strconv.Unquote(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %s", part)
+			}
+			part = unquoted
+		case strings.HasPrefix(part, "'") && strings.HasSuffix(part, "'") && len(part) > 1:
+			part = part[1 : len(part)-1]
+		case !bareTOMLKey.MatchString(part):
+			return nil, fmt.Errorf("invalid key %q", part)
+		}
+		parts = append(parts, part)
+		if split < 0 {
+			return parts, nil
+		}
+		key = key[split+1:]
+	}
+}
+
+// parseTOMLValue parses the value of a key, returning numbers as float64 like encoding/json does
+func parseTOMLValue(text string) (interface{}, error) {
+	switch {
+	case text == "":
+		return nil, errors.New("missing value")
+	case strings.HasPrefix(text, `"""`) || strings.HasPrefix(text, "'''"):
+		return nil, errors.New("multi-line strings are not supported")
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") || strings.Contains(text[1:len(text)-1], "'") {
+			return nil, fmt.Errorf("invalid string %s", text)
+		}
+		return text[1 : len(text)-1], nil
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, errors.New("unterminated array")
+		}
+		var values []interface{}
+		for _, element := range splitUnquoted(text[1:len(text)-1], ',') {
+			value, err := parseTOMLValue(element)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return nil, errors.New("unterminated inline table")
+		}
+		table := make(map[string]interface{})
+		for _, entry := range splitUnquoted(text[1:len(text)-1], ',') {
+			if err := setTOMLValue(table, entry); err != nil {
+				return nil, err
+			}
+		}
+		return table, nil
+	case text == "true":
+		return true, nil
+	case text == "false":
+		return false, nil
+	}
+	return parseNumber(strings.ReplaceAll(text, "_", ""))
+}
+
+// writeTOMLTable writes the values of a table, followed by its sub tables
+func writeTOMLTable(out *strings.Builder, path []string, values map[string]interface{}) error {
+	var tables []string
+	header := len(path) > 0 && len(values) == 0
+	for _, key := range sortedKeys(values) {
+		if _, ok := values[key].(map[string]interface{}); ok {
+			tables = append(tables, key)
+			continue
+		}
+		if len(path) > 0 {
+			header = true
+		}
+	}
+	if header {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		quoted := make([]string, len(path))
+		for i, part := range path {
+			quoted[i] = tomlKey(part)
+		}
+		fmt.Fprintf(out, "[%s]\n", strings.Join(quoted, "."))
+	}
+	for _, key := range sortedKeys(values) {
+		if _, ok := values[key].(map[string]interface{}); ok {
+			continue
+		}
+		text, err := formatTOMLValue(values[key])
+		if err != nil {
+			return ConfigError{Key: strings.Join(append(path[:len(path):len(path)], key), "."), Err: err}
+		}
+		fmt.Fprintf(out, "%s = %s\n", tomlKey(key), text)
+	}
+	for _, key := range tables {
+		if err := writeTOMLTable(out, append(path[:len(path):len(path)], key), values[key].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatTOMLValue formats a plain value, see plainValue
+func formatTOMLValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", errors.New("TOML has no null value")
+	case []interface{}:
+		elements := make([]string, len(value))
+		for i, element := range value {
+			text, err := formatTOMLValue(element)
+			if err != nil {
+				return "", err
+			}
+			elements[i] = text
+		}
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	case map[string]interface{}:
+		entries := make([]string, 0, len(value))
+		for _, key := range sortedKeys(value) {
+			text, err := formatTOMLValue(value[key])
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, tomlKey(key)+" = "+text)
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return "", fmt.Errorf("%v can not be written", value)
+		}
+	}
+	return formatScalar(value), nil
+}
+
+// tomlKey quotes a key if it needs to be
+func tomlKey(key string) string {
+	if bareTOMLKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// YAMLFormat reads and writes YAML config files. It covers the block style config files use: nested mappings,
+// sequences, and plain, quoted and flow scalars. Anchors, tags and multi-line strings are not supported
+type YAMLFormat struct{}
+
+// yamlLine is a line of a YAML document, without its indentation and comment
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// Unmarshal implements the ConfigFormat interface
+func (YAMLFormat) Unmarshal(data []byte) (map[string]interface{}, error) {
+	var lines []yamlLine
+	for i, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := strings.TrimSpace(stripComment(line))
+		if text == "" || text == "---" {
+			continue
+		}
+		if text == "..." {
+			break
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.HasPrefix(line[indent:], "\t") {
+			return nil, fmt.Errorf("line %d: tabs can not be used for indentation", i+1)
+		}
+		lines = append(lines, yamlLine{number: i + 1, indent: indent, text: text})
+	}
+	if len(lines) == 0 {
+		return make(map[string]interface{}), nil
+	}
+
+	value, rest, err := parseYAMLBlock(lines, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("line %d: unexpected indentation", rest[0].number)
+	}
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected a mapping at the top of the document")
+	}
+	return values, nil
+}
+
+// Marshal implements the ConfigFormat interface
+func (YAMLFormat) Marshal(values map[string]interface{}) ([]byte, error) {
+	plain, err := plainValue(reflect.ValueOf(values))
+	if err != nil {
+		return nil, err
+	}
+	var out strings.Builder
+	writeYAMLMapping(&out, 0, plain.(map[string]interface{}))
+	return []byte(out.String()), nil
+}
+
+// parseYAMLBlock parses the mapping or sequence starting at lines[0], which sits at indent.
+// Returns the lines after the block
+func parseYAMLBlock(lines []yamlLine, indent int) (interface{}, []yamlLine, error) {
+	if isYAMLItem(lines[0].text) {
+		var values []interface{}
+		for len(lines) > 0 && lines[0].indent == indent && isYAMLItem(lines[0].text) {
+			line := lines[0]
+			item := strings.TrimLeft(line.text[1:], " ")
+			lines = lines[1:]
+			var value interface{}
+			var err error
+			switch {
+			case item == "":
+				if len(lines) > 0 && lines[0].indent > indent {
+					value, lines, err = parseYAMLBlock(lines, lines[0].indent)
+				}
+			case yamlKeyIndex(item) >= 0 || isYAMLItem(item):
+				// A block starting on the item's line, i.e. "- name: value". Treat it as if it began on a line of its own
+				itemIndent := indent + len(line.text) - len(item)
+				value, lines, err = parseYAMLBlock(append([]yamlLine{{line.number, itemIndent, item}}, lines...), itemIndent)
+			default:
+				value, err = parseYAMLScalar(item)
+			}
+			if err != nil {
+				return nil, nil, wrapYAMLError(line, err)
+			}
+			values = append(values, value)
+		}
+		return values, lines, nil
+	}
+
+	values := make(map[string]interface{})
+	for len(lines) > 0 && lines[0].indent == indent && !isYAMLItem(lines[0].text) {
+		line := lines[0]
+		split := yamlKeyIndex(line.text)
+		if split < 0 {
+			return nil, nil, fmt.Errorf("line %d: expected key: value, got %q", line.number, line.text)
+		}
+		key, err := parseYAMLScalar(strings.TrimSpace(line.text[:split]))
+		if err != nil {
+			return nil, nil, wrapYAMLError(line, err)
+		}
+		name := fmt.Sprint(key)
+		if _, ok := values[name]; ok {
+			return nil, nil, fmt.Errorf("line %d: key %s is defined twice", line.number, name)
+		}
+		text := strings.TrimSpace(line.text[split+1:])
+		lines = lines[1:]
+		var value interface{}
+		switch {
+		case text != "":
+			value, err = parseYAMLScalar(text)
+		case len(lines) > 0 && lines[0].indent > indent:
+			value, lines, err = parseYAMLBlock(lines, lines[0].indent)
+		case len(lines) > 0 && lines[0].indent == indent && isYAMLItem(lines[0].text):
+			// Sequences may sit at the same indentation as their key
+			value, lines, err = parseYAMLBlock(lines, indent)
+		}
+		if err != nil {
+			return nil, nil, wrapYAMLError(line, err)
+		}
+		values[name] = value
+	}
+	if len(lines) > 0 && lines[0].indent > indent {
+		return nil, nil, fmt.Errorf("line %d: unexpected indentation", lines[0].number)
+	}
+	return values, lines, nil
+}
+
+// wrapYAMLError adds the line number to errors that don't have one yet
+func wrapYAMLError(line yamlLine, err error) error {
+	if strings.HasPrefix(err.Error(), "line ") {
+		return err
+	}
+	return fmt.Errorf("line %d: %s", line.number, err.Error())
+}
+
+// isYAMLItem returns whether a line is a sequence item
+func isYAMLItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlKeyIndex returns the index of the colon ending a mapping key, or -1 if text is not a mapping entry
+func yamlKeyIndex(text string) int {
+	for offset := 0; ; {
+		split := unquotedIndex(text[offset:], ':', false)
+		if split < 0 {
+			return -1
+		}
+		split += offset
+		if split+1 == len(text) || text[split+1] == ' ' {
+			return split
+		}
+		offset = split + 1
+	}
+}
+
+// yamlNumber matches plain scalars that are decimal numbers
+var yamlNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+// parseYAMLScalar parses a single value: a quoted or plain scalar, or a flow sequence or mapping
+func parseYAMLScalar(text string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, errors.New("unterminated flow sequence")
+		}
+		values := []interface{}{}
+		for _, element := range splitUnquoted(text[1:len(text)-1], ',') {
+			value, err := parseYAMLScalar(element)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return nil, errors.New("unterminated flow mapping")
+		}
+		values := make(map[string]interface{})
+		for _, entry := range splitUnquoted(text[1:len(text)-1], ',') {
+			split := yamlKeyIndex(entry)
+			if split < 0 {
+				return nil, fmt.Errorf("expected key: value, got %q", entry)
+			}
+			key, err := parseYAMLScalar(strings.TrimSpace(entry[:split]))
+			if err != nil {
+				return nil, err
+			}
+			value, err := parseYAMLScalar(strings.TrimSpace(entry[split+1:]))
+			if err != nil {
+				return nil, err
+			}
+			values[fmt.Sprint(key)] = value
+		}
+		return values, nil
+	case strings.ContainsAny(text[:1], "|>&*!%@`"):
+		return nil, fmt.Errorf("unsupported value %s", text)
+	}
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", "+.inf", ".Inf", "+.Inf":
+		return math.Inf(1), nil
+	case "-.inf", "-.Inf":
+		return math.Inf(-1), nil
+	case ".nan", ".NaN":
+		return math.NaN(), nil
+	}
+	if yamlNumber.MatchString(text) || strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0o") {
+		return parseNumber(text)
+	}
+	return text, nil
+}
+
+// writeYAMLMapping writes a mapping in block style
+func writeYAMLMapping(out *strings.Builder, indent int, values map[string]interface{}) {
+	for _, key := range sortedKeys(values) {
+		prefix := strings.Repeat(" ", indent) + yamlKey(key) + ":"
+		switch value := values[key].(type) {
+		case map[string]interface{}:
+			if len(value) == 0 {
+				fmt.Fprintf(out, "%s {}\n", prefix)
+				continue
+			}
+			fmt.Fprintf(out, "%s\n", prefix)
+			writeYAMLMapping(out, indent+2, value)
+		case []interface{}:
+			if len(value) == 0 {
+				fmt.Fprintf(out, "%s []\n", prefix)
+				continue
+			}
+			fmt.Fprintf(out, "%s\n", prefix)
+			for _, element := range value {
+				fmt.Fprintf(out, "%s- %s\n", strings.Repeat(" ", indent+2), formatYAMLValue(element))
+			}
+		default:
+			fmt.Fprintf(out, "%s %s\n", prefix, formatYAMLValue(value))
+		}
+	}
+}
+
+// formatYAMLValue formats a plain value in flow style, see plainValue
+func formatYAMLValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		elements := make([]string, len(value))
+		for i, element := range value {
+			elements[i] = formatYAMLValue(element)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		entries := make([]string, 0, len(value))
+		for _, key := range sortedKeys(value) {
+			entries = append(entries, yamlKey(key)+": "+formatYAMLValue(value[key]))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case float64:
+		switch {
+		case math.IsInf(value, 1):
+			return ".inf"
+		case math.IsInf(value, -1):
+			return "-.inf"
+		case math.IsNaN(value):
+			return ".nan"
+		}
+	}
+	return formatScalar(value)
+}
+
+// plainYAMLKey matches keys that read back as the same string without quotes
+var plainYAMLKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// yamlKey quotes a key if it needs to be
+func yamlKey(key string) string {
+	if value, err := parseYAMLScalar(key); err == nil && value == key && plainYAMLKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// stripComment removes a # comment from the end of a line. Like YAML, a # only starts a comment at the start of the line or after a space
+func stripComment(line string) string {
+	end := len(line)
+	scanUnquoted(line, func(i int, _ int) bool {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			end = i
+			return false
+		}
+		return true
+	})
+	return line[:end]
+}
+
+// unquotedIndex returns the index of the first c in text that is outside quotes, or -1 if there is none.
+// Unless nested is set, occurrences inside brackets and braces are skipped too
+func unquotedIndex(text string, c byte, nested bool) int {
+	index := -1
+	scanUnquoted(text, func(i int, depth int) bool {
+		if text[i] == c && (nested || depth == 0) {
+			index = i
+			return false
+		}
+		return true
+	})
+	return index
+}
+
+// bracketDepth returns how many brackets and braces outside quotes are left open at the end of text
+func bracketDepth(text string) int {
+	return scanUnquoted(text, func(int, int) bool { return true })
+}
+
+// scanUnquoted calls fn with every byte of text outside quotes and how deep in brackets and braces it is, until fn returns false.
+// Quotes only count at the start of a value, so apostrophes in plain text don't start one. Returns the depth at the end
+func scanUnquoted(text string, fn func(i int, depth int) bool) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+		switch {
+		case quote != 0:
+			if char == '\\' && quote == '"' {
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+			continue
+		case (char == '"' || char == '\'') && (i == 0 || strings.IndexByte(" \t[{,:=", text[i-1]) >= 0):
+			quote = char
+			continue
+		}
+		if !fn(i, depth) {
+			return depth
+		}
+		switch char {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// splitUnquoted splits text at every sep outside quotes and brackets, trimming the parts and dropping a trailing empty one
+func splitUnquoted(text string, sep byte) []string {
+	var parts []string
+	for {
+		split := unquotedIndex(text, sep, false)
+		if split < 0 {
+			break
+		}
+		parts = append(parts, strings.TrimSpace(text[:split]))
+		text = text[split+1:]
+	}
+	if last := strings.TrimSpace(text); last != "" || len(parts) > 0 {
+		parts = append(parts, last)
+	}
+	if len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	return parts
+}
+
+// parseNumber parses an integer, with an optional 0x, 0o or 0b prefix, or a float. Numbers are returned as float64 like encoding/json does
+func parseNumber(text string) (interface{}, error) {
+	unsigned := strings.TrimLeft(text, "+-")
+	if strings.HasPrefix(unsigned, "0x") || strings.HasPrefix(unsigned, "0o") || strings.HasPrefix(unsigned, "0b") {
+		value, err := strconv.ParseInt(text, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", text)
+		}
+		return float64(value), nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return nil, fmt.Errorf("unsupported value %s", text)
+	}
+	return value, nil
+}
+
+// formatScalar formats a plain string, number or boolean
+func formatScalar(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value)
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// textMarshalerType is written as the text it marshals to
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// plainValue converts a value, as passed to Config.Set, into the types decoded config files hold: maps with string keys,
+// slices, strings, booleans, nil and numbers. Integers stay int64 or uint64 so they keep their precision
+func plainValue(value reflect.Value) (interface{}, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if value.Type().Implements(textMarshalerType) && (value.Kind() != reflect.Pointer || !value.IsNil()) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return plainValue(value.Elem())
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, value.Len())
+		for i := range values {
+			element, err := plainValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			values[i] = element
+		}
+		return values, nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		values := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			element, err := plainValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			values[iter.Key().String()] = element
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("%s can not be written to a config file", value.Type())
+}
+
+// sortedKeys returns the keys of a map in order, so files are written the same way every time
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
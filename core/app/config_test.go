@@ -0,0 +1,165 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type testConfig struct {
+	Audio struct {
+		Volume float64 `json:"volume"`
+		Muted  bool
+	} `json:"audio"`
+	Bindings map[string]string `json:"bindings"`
+}
+
+func TestConfigLayers(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(file, []byte(`{"audio": {"volume": 0.5, "muted": true}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIGTEST_AUDIO_VOLUME", "0.75")
+	config := &Config{File: file, EnvPrefix: "CONFIGTEST_", Args: []string{"-audio.muted=false", "-verbose"}, Formats: NewConfig("").Formats}
+
+	var settings testConfig
+	if err := config.Load(&settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings.Audio.Volume != 0.75 || settings.Audio.Muted {
+		t.Errorf("loaded %+v, want the environment's volume and the flag's muted", settings.Audio)
+	}
+}
+
+func TestConfigIgnoresUnknownEnvironment(t *testing.T) {
+	t.Setenv("CONFIGTEST_LOG", "1")
+	t.Setenv("CONFIGTEST_AUDIO_VOLUME", "0.25")
+	config := &Config{EnvPrefix: "CONFIGTEST_"}
+
+	var settings testConfig
+	if err := config.Load(&settings); err != nil {
+		t.Fatalf("a variable that is not a config key failed the load: %s", err.Error())
+	}
+	if settings.Audio.Volume != 0.25 {
+		t.Errorf("volume is %v, want 0.25", settings.Audio.Volume)
+	}
+}
+
+func TestConfigPreferencesKeepOneCase(t *testing.T) {
+	preferences := filepath.Join(t.TempDir(), "preferences.json")
+	if err := os.WriteFile(preferences, []byte(`{"Audio": {"VOLUME": 0.5}, "bindings": {"Jump": "space"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := &Config{PreferencesFile: preferences, Formats: NewConfig("").Formats}
+	var settings testConfig
+	if err := config.Load(&settings); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Set(&settings, "audio.Volume", 0.8); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Set(&settings, "AUDIO.muted", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Set(&settings, "bindings.Fire", "mouse1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"audio":    map[string]interface{}{"volume": 0.8, "Muted": true},
+		"bindings": map[string]interface{}{"Jump": "space", "Fire": "mouse1"},
+	}
+	if !reflect.DeepEqual(config.preferences, want) {
+		t.Errorf("preferences are %v, want %v", config.preferences, want)
+	}
+	if settings.Audio.Volume != 0.8 || !settings.Audio.Muted || settings.Bindings["Fire"] != "mouse1" {
+		t.Errorf("settings are %+v", settings)
+	}
+}
+
+func TestConfigIgnoresGroupFlags(t *testing.T) {
+	t.Setenv("CONFIGTEST_AUDIO", "loud")
+	config := &Config{EnvPrefix: "CONFIGTEST_", Args: []string{"-audio", "loud", "--audio.volume=0.5"}}
+	var settings testConfig
+	if err := config.Load(&settings); err != nil {
+		t.Fatalf("a flag naming a group of keys failed the load: %s", err.Error())
+	}
+	if settings.Audio.Volume != 0.5 {
+		t.Errorf("volume is %v, want 0.5", settings.Audio.Volume)
+	}
+}
+
+func TestConfigFormats(t *testing.T) {
+	for _, test := range []struct {
+		extension string
+		file      string
+	}{
+		{".toml", `
+# Shipped defaults
+[audio]
+volume = 0.5 # Half way
+Muted = true
+
+[bindings]
+jump = "space"
+"fire weapon" = 'mouse1'
+`},
+		{".yaml", `
+# Shipped defaults
+audio:
+  volume: 0.5 # Half way
+  Muted: true
+bindings:
+  jump: space
+  "fire weapon": 'mouse1'
+`},
+	} {
+		t.Run(test.extension, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config"+test.extension)
+			if err := os.WriteFile(file, []byte(test.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			config := NewConfig("")
+			config.File, config.Args = file, nil
+			var settings testConfig
+			if err := config.Load(&settings); err != nil {
+				t.Fatal(err)
+			}
+			if settings.Audio.Volume != 0.5 || !settings.Audio.Muted {
+				t.Errorf("loaded %+v", settings.Audio)
+			}
+			if settings.Bindings["jump"] != "space" || settings.Bindings["fire weapon"] != "mouse1" {
+				t.Errorf("loaded bindings %v", settings.Bindings)
+			}
+		})
+	}
+}
+
+func TestConfigFormatsRoundTrip(t *testing.T) {
+	values := map[string]interface{}{
+		"title":   "Surreal: the \"game\"",
+		"volume":  0.25,
+		"enabled": true,
+		"levels":  []interface{}{1.0, 2.0, 3.0},
+		"window": map[string]interface{}{
+			"size":  map[string]interface{}{"width": 1280.0, "height": 720.0},
+			"title": "it's #1",
+		},
+		"true": "a key that reads as a boolean",
+	}
+	for extension, format := range map[string]ConfigFormat{".toml": TOMLFormat{}, ".yaml": YAMLFormat{}} {
+		data, err := format.Marshal(values)
+		if err != nil {
+			t.Fatalf("%s: %s", extension, err.Error())
+		}
+		decoded, err := format.Unmarshal(data)
+		if err != nil {
+			t.Fatalf("%s: %s\n%s", extension, err.Error(), data)
+		}
+		if !reflect.DeepEqual(decoded, values) {
+			t.Errorf("%s round trip gave %v, want %v\n%s", extension, decoded, values, data)
+		}
+	}
+}
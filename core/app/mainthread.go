@@ -0,0 +1,17 @@
+package app
+
+import (
+	"github.com/gjh33/SurrealEngine/core/mainthread"
+)
+
+// RunOnMain queues fn to run on the main thread at the start of the next frame. Safe to call from any goroutine
+func RunOnMain(fn func()) {
+	mainthread.Call(fn)
+}
+
+// RunOnMainSync runs fn on the main thread and waits for it, returning its error. Safe to call from any goroutine,
+// including the main one, where it runs right away. i.e. to resize or retitle the window from async code.
+// Outside of Run only the main goroutine can run it, from any other fn is not run and a mainthread.NotBoundError returned
+func RunOnMainSync(fn func() error) error {
+	return mainthread.CallSync(fn)
+}
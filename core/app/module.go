@@ -0,0 +1,256 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gfx "github.com/gjh33/SurrealEngine/graphics"
+)
+
+// Module is an engine subsystem, i.e. graphics, audio, physics or scripting, registered with Application.Use.
+// Modules are initialized after ApplicationStartupEvent, dependencies first, and shut down in reverse order after ApplicationQuitEvent
+type Module interface {
+	Name() string           // Unique name other modules refer to this one by
+	Dependencies() []string // Names of the modules that must be initialized before this one
+	Init(application *Application) error
+	Shutdown(application *Application) error
+}
+
+// GraphicsModuleName is the name of the module that sets up the graphics context and window.
+// Unless a module by this name is registered, Run adds a GraphicsModule built from Options first
+const GraphicsModuleName = "graphics"
+
+// ModuleError is the error returned when a module fails to initialize or shut down
+type ModuleError struct {
+	Module string
+	Err    error
+}
+
+// Error implements the error interface
+func (err ModuleError) Error() string {
+	return fmt.Sprintf("module %s: %s", err.Module, err.Err.Error())
+}
+
+// Unwrap returns the error the module failed with
+func (err ModuleError) Unwrap() error {
+	return err.Err
+}
+
+// DuplicateModuleError is the error returned when a module is registered under a name already in use
+type DuplicateModuleError struct {
+	Name string
+}
+
+// Error implements the error interface
+func (err DuplicateModuleError) Error() string {
+	return fmt.Sprintf("module %s is already registered", err.Name)
+}
+
+// MissingDependencyError is the error returned when a module depends on one that was never registered
+type MissingDependencyError struct {
+	Module     string
+	Dependency string
+}
+
+// Error implements the error interface
+func (err MissingDependencyError) Error() string {
+	return fmt.Sprintf("module %s depends on %s, which is not registered", err.Module, err.Dependency)
+}
+
+// DependencyCycleError is the error returned when modules depend on each other in a loop
+type DependencyCycleError struct {
+	Cycle []string // Names of the modules in the loop, the first depending on the second and so on
+}
+
+// Error implements the error interface
+func (err DependencyCycleError) Error() string {
+	return fmt.Sprintf("module dependency cycle: %s -> %s", strings.Join(err.Cycle, " -> "), err.Cycle[0])
+}
+
+// Use registers modules with the application. They are initialized on the next Run, in dependency order, ties keeping the order they were registered in.
+// Returns a DuplicateModuleError if a name is already taken.
+func (application *Application) Use(modules ...Module) error {
+	if state := application.State(); state != StateCreated && state != StateStopped {
+		return fmt.Errorf("can not register modules while the application is %s", state)
+	}
+	for _, module := range modules {
+		if application.Module(module.Name()) != nil {
+			return DuplicateModuleError{Name: module.Name()}
+		}
+		application.modules = append(application.modules, module)
+	}
+	return nil
+}
+
+// Module returns the registered module with the given name, or nil if there is none
+func (application *Application) Module(name string) Module {
+	for _, module := range application.modules {
+		if module.Name() == name {
+			return module
+		}
+	}
+	return nil
+}
+
+// initModules initializes every registered module in dependency order.
+// If one fails, the ones already initialized are shut down again before returning
+func (application *Application) initModules() error {
+	modules := application.modules
+	if application.Module(GraphicsModuleName) == nil {
+		modules = append([]Module{&GraphicsModule{Context: application.Options.newContext()}}, modules...)
+	}
+	ordered, err := sortModules(modules)
+	if err != nil {
+		return err
+	}
+	for _, module := range ordered {
+		if err := module.Init(application); err != nil {
+			return errors.Join(ModuleError{Module: module.Name(), Err: err}, application.shutdownModules())
+		}
+		application.running = append(application.running, module)
+	}
+	return nil
+}
+
+// shutdownModules shuts down every initialized module in the reverse order they were initialized in.
+// Every module gets shut down even if others fail
+func (application *Application) shutdownModules() error {
+	var errs []error
+	for i := len(application.running) - 1; i >= 0; i-- {
+		module := application.running[i]
+		if err := module.Shutdown(application); err != nil {
+			errs = append(errs, ModuleError{Module: module.Name(), Err: err})
+		}
+	}
+	application.running = nil
+	return errors.Join(errs...)
+}
+
+// sortModules orders modules so each comes after its dependencies
+func sortModules(modules []Module) ([]Module, error) {
+	byName := make(map[string]Module, len(modules))
+	for _, module := range modules {
+		byName[module.Name()] = module
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int, len(modules))
+	ordered := make([]Module, 0, len(modules))
+	var path []string
+	var visit func(module Module) error
+	visit = func(module Module) error {
+		name := module.Name()
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == name {
+					return DependencyCycleError{Cycle: append([]string(nil), path[i:]...)}
+				}
+			}
+		}
+		marks[name] = visiting
+		path = append(path, name)
+		for _, dependency := range module.Dependencies() {
+			next, ok := byName[dependency]
+			if !ok {
+				return MissingDependencyError{Module: name, Dependency: dependency}
+			}
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+		ordered = append(ordered, module)
+		return nil
+	}
+	for _, module := range modules {
+		if err := visit(module); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// GraphicsModule initializes a graphics context and creates its window, both set up from Application.Settings. It is added to Application.Contexts while running
+type GraphicsModule struct {
+	Context gfx.Context // The context to initialize. Defaults to the one Options asks for
+}
+
+// Name implements the Module interface
+func (module *GraphicsModule) Name() string {
+	return GraphicsModuleName
+}
+
+// Dependencies implements the Module interface
+func (module *GraphicsModule) Dependencies() []string {
+	return nil
+}
+
+// Init implements the Module interface
+func (module *GraphicsModule) Init(application *Application) error {
+	if module.Context == nil {
+		module.Context = application.Options.newContext()
+	}
+	if configurable, ok := module.Context.(interface{ SetSettings(gfx.Settings) }); ok {
+		configurable.SetSettings(application.Settings.Graphics)
+	}
+	if err := module.Context.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize graphics context.\n Initialize Error: %s", err.Error())
+	}
+	window := module.Context.Window()
+	settings := application.Settings.Window
+	if settings.Title != "" {
+		if err := window.SetTitle(settings.Title); err != nil {
+			return fmt.Errorf("failed to set window title.\n SetTitle Error: %s", err.Error())
+		}
+	}
+	if len(settings.Icons) > 0 {
+		if err := window.SetIcons(settings.Icons); err != nil {
+			return fmt.Errorf("failed to set window icons.\n SetIcons Error: %s", err.Error())
+		}
+	}
+	if err := window.SetResizable(settings.Resizable); err != nil {
+		return fmt.Errorf("failed to set window resizable.\n SetResizable Error: %s", err.Error())
+	}
+	if err := window.SetDecorated(settings.Decorated); err != nil {
+		return fmt.Errorf("failed to set window decorated.\n SetDecorated Error: %s", err.Error())
+	}
+	if err := window.SetFullscreen(settings.FullScreen); err != nil {
+		return fmt.Errorf("failed to set window fullscreen.\n SetFullscreen Error: %s", err.Error())
+	}
+	if err := window.SetCursorLocked(settings.CursorLocked); err != nil {
+		return fmt.Errorf("failed to set cursor locked.\n SetCursorLocked Error: %s", err.Error())
+	}
+	if err := window.SetCursorHidden(settings.CursorHidden); err != nil {
+		return fmt.Errorf("failed to set cursor hidden.\n SetCursorHidden Error: %s", err.Error())
+	}
+	if err := window.Create(); err != nil {
+		return fmt.Errorf("failed to create window.\n Create Error: %s", err.Error())
+	}
+	application.Contexts = append(application.Contexts, module.Context)
+	return nil
+}
+
+// Shutdown implements the Module interface
+func (module *GraphicsModule) Shutdown(application *Application) error {
+	for i, context := range application.Contexts {
+		if context == module.Context {
+			application.Contexts = append(application.Contexts[:i:i], application.Contexts[i+1:]...)
+			break
+		}
+	}
+	if window := module.Context.Window(); window != nil && window.IsCreated() {
+		if err := window.Close(); err != nil {
+			return fmt.Errorf("failed to close window.\n Close Error: %s", err.Error())
+		}
+	}
+	return nil
+}
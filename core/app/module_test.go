@@ -0,0 +1,138 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+// testModule records its Init and Shutdown calls in log
+type testModule struct {
+	name         string
+	dependencies []string
+	initErr      error
+	log          *[]string
+}
+
+func (module *testModule) Name() string           { return module.name }
+func (module *testModule) Dependencies() []string { return module.dependencies }
+
+func (module *testModule) Init(*Application) error {
+	*module.log = append(*module.log, "init "+module.name)
+	return module.initErr
+}
+
+func (module *testModule) Shutdown(*Application) error {
+	*module.log = append(*module.log, "shutdown "+module.name)
+	return nil
+}
+
+func moduleNames(modules []Module) []string {
+	names := make([]string, len(modules))
+	for i, module := range modules {
+		names[i] = module.Name()
+	}
+	return names
+}
+
+func TestSortModules(t *testing.T) {
+	var log []string
+	ordered, err := sortModules([]Module{
+		&testModule{name: "physics", dependencies: []string{"scripting"}, log: &log},
+		&testModule{name: "audio", log: &log},
+		&testModule{name: "scripting", dependencies: []string{"audio"}, log: &log},
+		&testModule{name: "network", log: &log},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := moduleNames(ordered); !slices.Equal(names, []string{"audio", "scripting", "physics", "network"}) {
+		t.Errorf("modules sorted as %v, want dependencies first and ties in registration order", names)
+	}
+}
+
+func TestSortModulesCycle(t *testing.T) {
+	var log []string
+	_, err := sortModules([]Module{
+		&testModule{name: "audio", log: &log},
+		&testModule{name: "physics", dependencies: []string{"audio", "scripting"}, log: &log},
+		&testModule{name: "scripting", dependencies: []string{"physics"}, log: &log},
+	})
+	var cycle DependencyCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("sorting returned %v, want a DependencyCycleError", err)
+	}
+	if !slices.Equal(cycle.Cycle, []string{"physics", "scripting"}) {
+		t.Errorf("cycle reported as %v, want [physics scripting]", cycle.Cycle)
+	}
+}
+
+func TestSortModulesMissingDependency(t *testing.T) {
+	var log []string
+	_, err := sortModules([]Module{&testModule{name: "physics", dependencies: []string{"audio"}, log: &log}})
+	var missing MissingDependencyError
+	if !errors.As(err, &missing) || missing.Module != "physics" || missing.Dependency != "audio" {
+		t.Errorf("sorting returned %v, want a MissingDependencyError for audio", err)
+	}
+}
+
+func TestUseDuplicateModule(t *testing.T) {
+	var log []string
+	application := New("test", "1.0.0")
+	if err := application.Use(&testModule{name: "audio", log: &log}); err != nil {
+		t.Fatal(err)
+	}
+	var duplicate DuplicateModuleError
+	if err := application.Use(&testModule{name: "audio", log: &log}); !errors.As(err, &duplicate) {
+		t.Errorf("registering audio twice returned %v, want a DuplicateModuleError", err)
+	}
+}
+
+func TestInitModulesRollback(t *testing.T) {
+	var log []string
+	failure := errors.New("no audio device")
+	application := New("test", "1.0.0")
+	application.Options.Context = &testContext{new(win.NullWindow)}
+	err := application.Use(
+		&testModule{name: "scripting", log: &log},
+		&testModule{name: "audio", dependencies: []string{"scripting"}, initErr: failure, log: &log},
+		&testModule{name: "physics", dependencies: []string{"audio"}, log: &log},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = application.initModules()
+	var moduleErr ModuleError
+	if !errors.As(err, &moduleErr) || moduleErr.Module != "audio" || !errors.Is(err, failure) {
+		t.Fatalf("initializing returned %v, want the audio module's error", err)
+	}
+	if want := []string{"init scripting", "init audio", "shutdown scripting"}; !slices.Equal(log, want) {
+		t.Errorf("modules were called as %v, want %v", log, want)
+	}
+	if len(application.running) != 0 || len(application.Contexts) != 0 {
+		t.Error("modules are still running after a failed initialization")
+	}
+}
+
+// unresizableWindow is a NullWindow that refuses to be made resizable
+type unresizableWindow struct {
+	win.NullWindow
+}
+
+func (window *unresizableWindow) SetResizable(bool) error {
+	return errors.New("not supported")
+}
+
+func TestGraphicsModuleSetupError(t *testing.T) {
+	window := new(unresizableWindow)
+	module := &GraphicsModule{Context: &testContext{window}}
+	if err := module.Init(New("test", "1.0.0")); err == nil {
+		t.Fatal("the graphics module ignored a failing window setting")
+	}
+	if window.IsCreated() {
+		t.Error("the window was created despite the failing setting")
+	}
+}
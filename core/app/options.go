@@ -0,0 +1,23 @@
+package app
+
+import (
+	gfx "github.com/gjh33/SurrealEngine/graphics"
+)
+
+// Options control how an Application runs. Set them before calling Start.
+type Options struct {
+	Headless bool                    // Run without a window or GPU, i.e. for dedicated servers, CI and batch tools
+	Context  gfx.Context             // Graphics context to render with. Defaults to Vulkan, or a gfx.NullContext when headless
+	ExitWhen func(*Application) bool // Checked at the end of every frame. The application quits once it returns true
+}
+
+// newContext returns the graphics context the options ask for
+func (options Options) newContext() gfx.Context {
+	if options.Context != nil {
+		return options.Context
+	}
+	if options.Headless {
+		return &gfx.NullContext{}
+	}
+	return &gfx.VulkanContext{}
+}
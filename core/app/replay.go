@@ -0,0 +1,30 @@
+package app
+
+import (
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+// replayTarget routes replayed events back to the dispatcher that originally raised them
+type replayTarget struct {
+	application *Application
+	window      win.Window
+}
+
+// Subscribe implements the event.Dispatcher interface
+func (target *replayTarget) Subscribe(subscriber event.Subscriber) (*event.Subscription, error) {
+	return target.application.Subscribe(subscriber)
+}
+
+// Dispatch implements the event.Dispatcher interface
+func (target *replayTarget) Dispatch(e event.Event) (bool, error) {
+	switch e.(type) {
+	case ApplicationStartupEvent, ApplicationInitializedEvent, ApplicationFixedUpdateEvent, ApplicationUpdateEvent, ApplicationStateChangedEvent, ApplicationQuitEvent, ApplicationCleanedUpEvent:
+		// The application raises its own life cycle events while replaying, so recorded ones are only informative
+		return false, nil
+	}
+	if win.IsWindowEvent(e) {
+		return target.window.Dispatch(win.Retarget(e, target.window))
+	}
+	return target.application.Dispatch(e)
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+func TestReplayTarget(t *testing.T) {
+	application := New("replay", "1.0.0")
+	window := &win.VulkanWindow{}
+	target := &replayTarget{application, window}
+
+	var resized []win.Window
+	if _, err := window.Subscribe(resizeRecorder(func(e win.WindowResizedEvent) { resized = append(resized, e.Window) })); err != nil {
+		t.Fatal(err)
+	}
+	updates := 0
+	if _, err := application.Subscribe(updateCounter(func() { updates++ })); err != nil {
+		t.Fatal(err)
+	}
+
+	// Window events read back from a recording have lost their window
+	if _, err := target.Dispatch(win.WindowResizedEvent{NewSize: win.Size{Width: 1, Height: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(resized) != 1 || resized[0] != window {
+		t.Errorf("replayed window event reached the window as %v, want it retargeted to the window", resized)
+	}
+	if _, err := target.Dispatch(ApplicationUpdateEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	if updates != 0 {
+		t.Error("a recorded life cycle event was dispatched again")
+	}
+}
+
+type resizeRecorder func(e win.WindowResizedEvent)
+
+func (fn resizeRecorder) OnWindowResized(e win.WindowResizedEvent) { fn(e) }
+
+type updateCounter func()
+
+func (fn updateCounter) OnApplicationUpdate(ApplicationUpdateEvent) { fn() }
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Migration upgrades save data from the layout one version wrote to the layout a later version expects.
+// See MigrateAs to work with typed data instead of raw JSON
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// Migrations is a registry of upgrades between save data versions. The zero value is an empty, usable registry.
+//
+// A migration from A to B applies to data written by any version from A up to, not including, B, and brings it to B.
+// On load, migrations are chained until the data reaches the running version, so only versions that change the layout need one:
+// data from a version without a migration is taken to have the layout of the last migration before it.
+type Migrations struct {
+	steps []migrationStep // Sorted by from
+}
+
+// migrationStep is a registered migration
+type migrationStep struct {
+	from SemanticVersion
+	to   SemanticVersion
+	fn   Migration
+}
+
+// saveEnvelope is how save data is stored, stamped with the version that wrote it
+type saveEnvelope struct {
+	Version SemanticVersion `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// NewerVersionError is the error returned when loading data written by a newer version than the one running
+type NewerVersionError struct {
+	Data    SemanticVersion // The version that wrote the data
+	Running SemanticVersion // The version trying to read it
+}
+
+// Error implements the error interface
+func (err NewerVersionError) Error() string {
+	return fmt.Sprintf("data was saved by version %s, which is newer than the running version %s. Update to load it", err.Data, err.Running)
+}
+
+// MigrationError is the error returned when a migration fails
+type MigrationError struct {
+	From SemanticVersion
+	To   SemanticVersion
+	Err  error
+}
+
+// Error implements the error interface
+func (err MigrationError) Error() string {
+	return fmt.Sprintf("failed to migrate data from version %s to %s: %s", err.From, err.To, err.Err.Error())
+}
+
+// Unwrap returns the error the migration failed with
+func (err MigrationError) Unwrap() error {
+	return err.Err
+}
+
+// Register adds a migration bringing data from version from to version to, which must be higher
+func (migrations *Migrations) Register(from SemanticVersion, to SemanticVersion, fn Migration) error {
+	if !from.Less(to) {
+		return fmt.Errorf("migration from %s to %s does not upgrade", from, to)
+	}
+	for _, step := range migrations.steps {
+		if step.from.Compare(from) == 0 && step.to.Compare(to) == 0 {
+			return fmt.Errorf("migration from %s to %s is already registered", from, to)
+		}
+	}
+	migrations.steps = append(migrations.steps, migrationStep{from, to, fn})
+	sort.SliceStable(migrations.steps, func(i, j int) bool {
+		return migrations.steps[i].from.Less(migrations.steps[j].from)
+	})
+	return nil
+}
+
+// Migrate upgrades data written by version from to the layout expected by version to, chaining every migration on the way
+func (migrations *Migrations) Migrate(data json.RawMessage, from SemanticVersion, to SemanticVersion) (json.RawMessage, error) {
+	if to.Less(from) {
+		return nil, NewerVersionError{Data: from, Running: to}
+	}
+	for {
+		step, ok := migrations.next(from, to)
+		if !ok {
+			return data, nil
+		}
+		migrated, err := step.fn(data)
+		if err != nil {
+			return nil, MigrationError{From: from, To: step.to, Err: err}
+		}
+		data, from = migrated, step.to
+	}
+}
+
+// next returns the migration to apply to data at version at, on the way to version to.
+// Of those that apply, the one registered from the highest version is picked, as it is the most specific.
+// If none does, the layout hasn't changed since at, so the next migration up applies
+func (migrations *Migrations) next(at SemanticVersion, to SemanticVersion) (migrationStep, bool) {
+	for i := len(migrations.steps) - 1; i >= 0; i-- {
+		step := migrations.steps[i]
+		if step.from.Compare(at) <= 0 && at.Less(step.to) && step.to.Compare(to) <= 0 {
+			return step, true
+		}
+	}
+	for _, step := range migrations.steps {
+		if at.Less(step.from) && step.to.Compare(to) <= 0 {
+			return step, true
+		}
+	}
+	return migrationStep{}, false
+}
+
+// MigrateAs adapts a typed upgrade into a Migration, i.e. MigrateAs(func(old SaveV1) (SaveV2, error) {...})
+func MigrateAs[From any, To any](fn func(From) (To, error)) Migration {
+	return func(data json.RawMessage) (json.RawMessage, error) {
+		var old From
+		if err := json.Unmarshal(data, &old); err != nil {
+			return nil, err
+		}
+		upgraded, err := fn(old)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(upgraded)
+	}
+}
+
+// WriteVersioned writes v as JSON, stamped with the version writing it
+func WriteVersioned(w io.Writer, version SemanticVersion, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode save data.\n Marshal Error: %s", err.Error())
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(saveEnvelope{Version: version, Data: data})
+}
+
+// ReadVersioned reads data written by WriteVersioned into v, migrating it up to the running version first.
+// Returns a NewerVersionError if the data was written by a newer version
+func (migrations *Migrations) ReadVersioned(r io.Reader, running SemanticVersion, v interface{}) error {
+	var envelope saveEnvelope
+	if err := json.NewDecoder(r).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to read save data.\n Decode Error: %s", err.Error())
+	}
+	if envelope.Data == nil {
+		return fmt.Errorf("save data is not versioned")
+	}
+	data, err := migrations.Migrate(envelope.Data, envelope.Version, running)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode save data.\n Unmarshal Error: %s", err.Error())
+	}
+	return nil
+}
+
+// Save writes v stamped with the application's version
+func (application *Application) Save(w io.Writer, v interface{}) error {
+	return WriteVersioned(w, application.Version, v)
+}
+
+// Load reads data written by Save into v, running the application's Migrations on data from older versions
+func (application *Application) Load(r io.Reader, v interface{}) error {
+	return application.Migrations.ReadVersioned(r, application.Version, v)
+}
+
+// SaveFile is Save to a file. The file is replaced in one step, so a crash mid save never leaves it half written.
+// A new file is readable by everyone like os.Create makes it, a replaced one keeps its permissions
+func (application *Application) SaveFile(path string, v interface{}) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := application.Save(file, v); err != nil {
+		_ = file.Close()
+		return err
+	}
+	// Temporary files are only readable by their owner
+	if err := file.Chmod(mode); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// LoadFile is Load from a file
+func (application *Application) LoadFile(path string, v interface{}) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return application.Load(file, v)
+}
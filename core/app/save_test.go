@@ -0,0 +1,151 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type saveV1 struct {
+	Name string
+}
+
+type saveV2 struct {
+	First string
+	Last  string
+}
+
+type saveV3 struct {
+	First string
+	Last  string
+	Level int
+}
+
+// testMigrations upgrades saveV1 data at 1.0.0 to saveV2 at 1.1.0, and saveV2 data from 2.0.0 on to saveV3 at 3.0.0
+func testMigrations(t *testing.T) *Migrations {
+	t.Helper()
+	migrations := new(Migrations)
+	err := migrations.Register(MustParseVersion("1.0.0"), MustParseVersion("1.1.0"), MigrateAs(func(old saveV1) (saveV2, error) {
+		first, last, _ := strings.Cut(old.Name, " ")
+		return saveV2{First: first, Last: last}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = migrations.Register(MustParseVersion("2.0.0"), MustParseVersion("3.0.0"), MigrateAs(func(old saveV2) (saveV3, error) {
+		return saveV3{First: old.First, Last: old.Last, Level: 1}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return migrations
+}
+
+// readAs writes v stamped with version written, then reads it back as running
+func readAs(migrations *Migrations, written string, running string, v interface{}, out interface{}) error {
+	var buffer bytes.Buffer
+	if err := WriteVersioned(&buffer, MustParseVersion(written), v); err != nil {
+		return err
+	}
+	return migrations.ReadVersioned(&buffer, MustParseVersion(running), out)
+}
+
+func TestMigrationChain(t *testing.T) {
+	var loaded saveV3
+	if err := readAs(testMigrations(t), "1.0.0", "3.0.0", saveV1{Name: "Ada Lovelace"}, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if want := (saveV3{First: "Ada", Last: "Lovelace", Level: 1}); loaded != want {
+		t.Errorf("loaded %+v, want %+v", loaded, want)
+	}
+}
+
+func TestMigrationMissingStep(t *testing.T) {
+	// Nothing is registered for 1.5.0, so its data still has the layout 1.1.0 migrated to, which 2.0.0's migration upgrades
+	var loaded saveV3
+	if err := readAs(testMigrations(t), "1.5.0", "3.0.0", saveV2{First: "Ada", Last: "Lovelace"}, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if want := (saveV3{First: "Ada", Last: "Lovelace", Level: 1}); loaded != want {
+		t.Errorf("loaded %+v, want %+v", loaded, want)
+	}
+
+	// Migrations past the running version are not applied
+	var partial saveV2
+	if err := readAs(testMigrations(t), "1.0.0", "2.5.0", saveV1{Name: "Ada Lovelace"}, &partial); err != nil {
+		t.Fatal(err)
+	}
+	if want := (saveV2{First: "Ada", Last: "Lovelace"}); partial != want {
+		t.Errorf("loaded %+v, want %+v", partial, want)
+	}
+}
+
+func TestMigrationNewerVersion(t *testing.T) {
+	var loaded saveV3
+	err := readAs(testMigrations(t), "4.0.0", "3.0.0", saveV3{}, &loaded)
+	var newer NewerVersionError
+	if !errors.As(err, &newer) || newer.Data.String() != "4.0.0" || newer.Running.String() != "3.0.0" {
+		t.Errorf("loading data from a newer version returned %v, want a NewerVersionError", err)
+	}
+}
+
+func TestMigrationError(t *testing.T) {
+	failure := errors.New("corrupt")
+	migrations := new(Migrations)
+	_ = migrations.Register(MustParseVersion("1.0.0"), MustParseVersion("2.0.0"), func(json.RawMessage) (json.RawMessage, error) {
+		return nil, failure
+	})
+	var loaded saveV2
+	err := readAs(migrations, "1.0.0", "2.0.0", saveV1{}, &loaded)
+	var migrationErr MigrationError
+	if !errors.As(err, &migrationErr) || !errors.Is(err, failure) {
+		t.Errorf("a failing migration returned %v, want a MigrationError", err)
+	}
+	if err := migrations.Register(MustParseVersion("2.0.0"), MustParseVersion("1.0.0"), nil); err == nil {
+		t.Error("registered a migration that downgrades")
+	}
+}
+
+func TestSaveFileRoundTrip(t *testing.T) {
+	application := New("test", "1.2.0")
+	path := filepath.Join(t.TempDir(), "save.json")
+	saved := saveV3{First: "Ada", Last: "Lovelace", Level: 7}
+	if err := application.SaveFile(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	var loaded saveV3
+	if err := application.LoadFile(path, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded != saved {
+		t.Errorf("loaded %+v, want %+v", loaded, saved)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o644 {
+		t.Errorf("a new save file has mode %v, want 0644", info.Mode().Perm())
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := application.SaveFile(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("replacing a save file changed its mode to %v", info.Mode().Perm())
+	}
+}
@@ -1,46 +1,231 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
 
-// ParseVersion returns a SemanticVersion parsed from a string in the format "X.X.X" where X are integers
+// ParseVersion returns a SemanticVersion parsed from a string in the format "X.Y.Z[-pre.release][+build.metadata]"
 func ParseVersion(str string) (SemanticVersion, error) {
 	ver := SemanticVersion{}
 	err := ver.Parse(str)
 	return ver, err
 }
 
+// MustParseVersion is ParseVersion, panicking on failure. For version literals in code
+func MustParseVersion(str string) SemanticVersion {
+	ver, err := ParseVersion(str)
+	if err != nil {
+		panic(err)
+	}
+	return ver
+}
+
 // SemanticVersion represents the versioning of software using Semantic Versioning 2.0.0
 // See https://semver.org/ for more information regarding semantic versioning
+//
+// Use Equal or Compare rather than == to compare versions. == also compares build metadata, which semver ignores,
+// and numbers too large for an int only differ in an unexported field, so they are not reliable in struct literals either.
+// Parse versions like that from strings instead.
 type SemanticVersion struct {
 	MajorRelease int
 	MinorRelease int
 	Patch        int
+	PreRelease   string // Dot separated pre-release identifiers, i.e. "beta.1". Empty for a normal release
+	Build        string // Dot separated build metadata, i.e. "build.5". Ignored when comparing versions
+
+	// Exact digits of MajorRelease, MinorRelease and Patch when they are too large for an int, in which case the int holds math.MaxInt.
+	// Semver puts no limit on them, so they are kept for String and Compare
+	overflow [3]string
 }
 
 // String implements the string representation of a semantic version
 func (version SemanticVersion) String() string {
-	return fmt.Sprintf("%v.%v.%v", version.MajorRelease, version.MinorRelease, version.Patch)
+	str := fmt.Sprintf("%v.%v.%v", version.number(0), version.number(1), version.number(2))
+	if version.PreRelease != "" {
+		str += "-" + version.PreRelease
+	}
+	if version.Build != "" {
+		str += "+" + version.Build
+	}
+	return str
 }
 
-// Parse parses the values for the version from a string in the format of "X.X.X" where X are integers
-func (version SemanticVersion) Parse(str string) error {
-	substrs := strings.Split(str, ".")
+// Parse parses the values for the version from a string in the format of "X.Y.Z[-pre.release][+build.metadata]"
+// Numbers too large for an int are stored as math.MaxInt, but still printed and compared exactly
+func (version *SemanticVersion) Parse(str string) error {
+	parsed := SemanticVersion{}
+	rest := str
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		parsed.Build = rest[i+1:]
+		rest = rest[:i]
+		if err := checkIdentifiers(parsed.Build, false); err != nil {
+			return fmt.Errorf("failed to parse Build from string \"%s\".\n %s", str, err.Error())
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		parsed.PreRelease = rest[i+1:]
+		rest = rest[:i]
+		if err := checkIdentifiers(parsed.PreRelease, true); err != nil {
+			return fmt.Errorf("failed to parse PreRelease from string \"%s\".\n %s", str, err.Error())
+		}
+	}
+
+	substrs := strings.Split(rest, ".")
+	if len(substrs) != 3 {
+		return fmt.Errorf("failed to parse version from string \"%s\". Expected MajorRelease.MinorRelease.Patch", str)
+	}
 	var err error
-	version.MajorRelease, err = strconv.Atoi(substrs[0])
-	if err != nil {
-		return fmt.Errorf("failed to parse MajorRelease from string \"%s\".\n Atoi Error: %s", substrs[0], err.Error())
+	if parsed.MajorRelease, parsed.overflow[0], err = parseVersionNumber(substrs[0]); err != nil {
+		return fmt.Errorf("failed to parse MajorRelease from string \"%s\".\n %s", substrs[0], err.Error())
 	}
-	version.MinorRelease, err = strconv.Atoi(substrs[1])
-	if err != nil {
-		return fmt.Errorf("failed to parse MinorRelease from string \"%s\".\n Atoi Error: %s", substrs[1], err.Error())
+	if parsed.MinorRelease, parsed.overflow[1], err = parseVersionNumber(substrs[1]); err != nil {
+		return fmt.Errorf("failed to parse MinorRelease from string \"%s\".\n %s", substrs[1], err.Error())
+	}
+	if parsed.Patch, parsed.overflow[2], err = parseVersionNumber(substrs[2]); err != nil {
+		return fmt.Errorf("failed to parse Patch from string \"%s\".\n %s", substrs[2], err.Error())
+	}
+	*version = parsed
+	return nil
+}
+
+// Compare returns -1, 0 or 1 if the version has lower, equal or higher precedence than other, following semver rules.
+// Build metadata is ignored, so 1.0.0+a and 1.0.0+b compare as equal
+func (version SemanticVersion) Compare(other SemanticVersion) int {
+	for i := range version.overflow {
+		if c := compareNumeric(version.number(i), other.number(i)); c != 0 {
+			return c
+		}
+	}
+	// A pre-release comes before its normal release
+	switch {
+	case version.PreRelease == other.PreRelease:
+		return 0
+	case version.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	}
+	ours, theirs := strings.Split(version.PreRelease, "."), strings.Split(other.PreRelease, ".")
+	for i := 0; i < len(ours) && i < len(theirs); i++ {
+		if c := compareIdentifier(ours[i], theirs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(ours), len(theirs))
+}
+
+// Equal returns if the version has the same precedence as other, ignoring build metadata like Compare
+func (version SemanticVersion) Equal(other SemanticVersion) bool {
+	return version.Compare(other) == 0
+}
+
+// Less returns if the version has lower precedence than other
+func (version SemanticVersion) Less(other SemanticVersion) bool {
+	return version.Compare(other) < 0
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (version SemanticVersion) MarshalText() ([]byte, error) {
+	return []byte(version.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (version *SemanticVersion) UnmarshalText(text []byte) error {
+	return version.Parse(string(text))
+}
+
+// number returns the digits of MajorRelease, MinorRelease or Patch by index
+func (version SemanticVersion) number(i int) string {
+	numbers := [3]int{version.MajorRelease, version.MinorRelease, version.Patch}
+	if numbers[i] == math.MaxInt && version.overflow[i] != "" {
+		return version.overflow[i]
+	}
+	return strconv.Itoa(numbers[i])
+}
+
+// parseVersionNumber parses a numeric part of a version, which may not have leading zeros.
+// Numbers too large for an int return math.MaxInt, along with their digits
+func parseVersionNumber(str string) (number int, overflow string, err error) {
+	if !isNumeric(str) {
+		return 0, "", fmt.Errorf("\"%s\" is not a number", str)
+	}
+	if len(str) > 1 && str[0] == '0' {
+		return 0, "", fmt.Errorf("\"%s\" has a leading zero", str)
+	}
+	number, err = strconv.Atoi(str)
+	if errors.Is(err, strconv.ErrRange) {
+		return math.MaxInt, str, nil
 	}
-	version.Patch, err = strconv.Atoi(substrs[2])
 	if err != nil {
-		return fmt.Errorf("failed to parse Patch from string \"%s\".\n Atoi Error: %s", substrs[2], err.Error())
+		return 0, "", fmt.Errorf("Atoi Error: %s", err.Error())
+	}
+	return number, "", nil
+}
+
+// checkIdentifiers makes sure a dot separated list of identifiers is valid. Numeric pre-release identifiers may not have leading zeros
+func checkIdentifiers(str string, preRelease bool) error {
+	for _, identifier := range strings.Split(str, ".") {
+		if identifier == "" {
+			return fmt.Errorf("empty identifier in \"%s\"", str)
+		}
+		for _, r := range identifier {
+			if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && r != '-' {
+				return fmt.Errorf("invalid character %q in \"%s\"", r, identifier)
+			}
+		}
+		if preRelease && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("\"%s\" has a leading zero", identifier)
+		}
 	}
 	return nil
 }
+
+// isNumeric returns if a string is made of only digits
+func isNumeric(str string) bool {
+	if str == "" {
+		return false
+	}
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareIdentifier compares pre-release identifiers. Numbers compare numerically and before alphanumerics, which compare in ASCII order
+func compareIdentifier(a string, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		return compareNumeric(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNumeric compares numbers of any size written without leading zeros, so the longer number is larger
+func compareNumeric(a string, b string) int {
+	if c := compareInt(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareInt returns -1, 0 or 1 if a is less than, equal to or greater than b
+func compareInt(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"math"
+	"testing"
+)
+
+// The valid and invalid versions from the semver.org regular expression tests
+var (
+	validVersions = []string{
+		"0.0.4",
+		"1.2.3",
+		"10.20.30",
+		"1.1.2-prerelease+meta",
+		"1.1.2+meta",
+		"1.1.2+meta-valid",
+		"1.0.0-alpha",
+		"1.0.0-beta",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha.beta.1",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha0.valid",
+		"1.0.0-alpha.0valid",
+		"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+		"1.0.0-rc.1+build.1",
+		"2.0.0-rc.1+build.123",
+		"1.2.3-beta",
+		"10.2.3-DEV-SNAPSHOT",
+		"1.2.3-SNAPSHOT-123",
+		"1.0.0",
+		"2.0.0",
+		"1.1.7",
+		"2.0.0+build.1848",
+		"2.0.1-alpha.1227",
+		"1.0.0-alpha+beta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+		"1.2.3----R-S.12.9.1--.12+meta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12",
+		"1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+		"99999999999999999999999.999999999999999999.99999999999999999",
+		"1.0.0-0A.is.legal",
+	}
+	invalidVersions = []string{
+		"1",
+		"1.2",
+		"1.2.3-0123",
+		"1.2.3-0123.0123",
+		"1.1.2+.123",
+		"+invalid",
+		"-invalid",
+		"-invalid+invalid",
+		"-invalid.01",
+		"alpha",
+		"alpha.beta",
+		"alpha.beta.1",
+		"alpha.1",
+		"alpha+beta",
+		"alpha_beta",
+		"alpha.",
+		"alpha..",
+		"beta",
+		"1.0.0-alpha_beta",
+		"-alpha.",
+		"1.0.0-alpha..",
+		"1.0.0-alpha..1",
+		"1.0.0-alpha...1",
+		"1.0.0-alpha....1",
+		"1.0.0-alpha.....1",
+		"1.0.0-alpha......1",
+		"1.0.0-alpha.......1",
+		"01.1.1",
+		"1.01.1",
+		"1.1.01",
+		"1.2.3.DEV",
+		"1.2-SNAPSHOT",
+		"1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788",
+		"1.2-RC-SNAPSHOT",
+		"-1.0.3-gamma+b7718",
+		"+justmeta",
+		"9.8.7+meta+meta",
+		"9.8.7-whatever+meta+meta",
+		"99999999999999999999999.999999999999999999.99999999999999999----RC-SNAPSHOT.12.09.1--------------------------------..12",
+		"",
+	}
+)
+
+func TestParseVersionValid(t *testing.T) {
+	for _, str := range validVersions {
+		version, err := ParseVersion(str)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) failed: %s", str, err.Error())
+			continue
+		}
+		if version.String() != str {
+			t.Errorf("ParseVersion(%q) prints as %q", str, version.String())
+		}
+	}
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	for _, str := range invalidVersions {
+		if version, err := ParseVersion(str); err == nil {
+			t.Errorf("ParseVersion(%q) accepted it as %s", str, version)
+		}
+	}
+}
+
+func TestVersionPrecedence(t *testing.T) {
+	// In increasing precedence, from the semver.org spec, plus numbers too large for an int
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+		"9223372036854775807.0.0",
+		"99999999999999999999998.0.0",
+		"99999999999999999999999.0.0",
+		"99999999999999999999999.0.1",
+		"100000000000000000000000.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, b := MustParseVersion(ordered[i]), MustParseVersion(ordered[j])
+			if got, want := a.Compare(b), compareInt(i, j); got != want {
+				t.Errorf("%s compared to %s is %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	if MustParseVersion("1.0.0+a").Compare(MustParseVersion("1.0.0+b")) != 0 {
+		t.Error("build metadata changed the precedence")
+	}
+}
+
+func TestVersionEqual(t *testing.T) {
+	for _, test := range []struct {
+		a, b  SemanticVersion
+		equal bool
+	}{
+		{MustParseVersion("1.2.3"), SemanticVersion{MajorRelease: 1, MinorRelease: 2, Patch: 3}, true},
+		{MustParseVersion("1.0.0+a"), MustParseVersion("1.0.0+b"), true},
+		{MustParseVersion("1.0.0-beta"), MustParseVersion("1.0.0"), false},
+		{MustParseVersion("9223372036854775807.0.0"), SemanticVersion{MajorRelease: math.MaxInt}, true},
+		{MustParseVersion("99999999999999999999999.0.0"), MustParseVersion("99999999999999999999999.0.0"), true},
+		{MustParseVersion("99999999999999999999998.0.0"), MustParseVersion("99999999999999999999999.0.0"), false},
+		{MustParseVersion("99999999999999999999999.0.0"), SemanticVersion{MajorRelease: math.MaxInt}, false},
+	} {
+		if got := test.a.Equal(test.b); got != test.equal {
+			t.Errorf("%s.Equal(%s) is %t, want %t", test.a, test.b, got, test.equal)
+		}
+	}
+}
+
+func TestVersionRangeContains(t *testing.T) {
+	for _, test := range []struct {
+		versionRange string
+		in, out      []string
+	}{
+		{"^1.2", []string{"1.2.0", "1.9.9"}, []string{"2.0.0", "2.0.0-beta", "1.1.9"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{">=1.0 <2.0", []string{"1.0.0", "1.5.0"}, []string{"2.0.0", "0.9.0", "1.0.0-rc.1"}},
+		{"1.x || >=3.0.0-beta", []string{"1.4.0", "3.0.0-beta", "4.0.0"}, []string{"2.0.0"}},
+		{"1.2 - 2.3", []string{"1.2.0", "2.3.9"}, []string{"2.4.0"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.5"}},
+		{"<=1.2", []string{"1.2.5"}, []string{"1.3.0"}},
+		{"*", []string{"0.0.0", "9.9.9"}, nil},
+		{"^99999999999999999999999", []string{"99999999999999999999999.5.0"}, []string{"100000000000000000000000.0.0"}},
+	} {
+		versionRange, err := ParseVersionRange(test.versionRange)
+		if err != nil {
+			t.Errorf("ParseVersionRange(%q) failed: %s", test.versionRange, err.Error())
+			continue
+		}
+		for _, str := range test.in {
+			if !versionRange.Contains(MustParseVersion(str)) {
+				t.Errorf("%q does not contain %s", test.versionRange, str)
+			}
+		}
+		for _, str := range test.out {
+			if versionRange.Contains(MustParseVersion(str)) {
+				t.Errorf("%q contains %s", test.versionRange, str)
+			}
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Services is a typed registry of engine services, i.e. the audio mixer, asset loader or physics world.
+// Services are looked up by the type they were provided as, so listeners and modules can reach them without globals.
+// Scopes made with NewScope see everything in their parents, so per-scene services can be layered on top of app-wide ones.
+// The zero value is an empty, usable registry.
+type Services struct {
+	parent  *Services
+	mutex   sync.RWMutex
+	entries map[reflect.Type]*service
+	owned   []*service // Services built from constructors, in the order they were built. Closed by Close
+}
+
+// ServiceScope is anything services can be provided to and looked up from: an *Application or *Services
+type ServiceScope interface {
+	services() *Services
+}
+
+// service is a single entry in the registry, either a value or a constructor that builds it on first use
+type service struct {
+	mutex       sync.Mutex
+	value       reflect.Value
+	built       bool
+	building    chan struct{} // While the constructor runs, closed once it returns
+	constructor reflect.Value
+}
+
+// ServiceNotFoundError is the error returned when a service type was never provided
+type ServiceNotFoundError struct {
+	Type reflect.Type
+}
+
+// Error implements the error interface
+func (err ServiceNotFoundError) Error() string {
+	return fmt.Sprintf("no service provided for %s", err.Type)
+}
+
+// ServiceCycleError is the error returned when service constructors depend on each other in a loop
+type ServiceCycleError struct {
+	Cycle []reflect.Type // The services in the loop, each needed to construct the one before it
+}
+
+// Error implements the error interface
+func (err ServiceCycleError) Error() string {
+	names := make([]string, len(err.Cycle))
+	for i, t := range err.Cycle {
+		names[i] = t.String()
+	}
+	return fmt.Sprintf("service dependency cycle: %s", strings.Join(names, " -> "))
+}
+
+// InvalidConstructorError is the error returned when a constructor isn't a function returning the service, optionally with an error
+type InvalidConstructorError struct {
+	Constructor reflect.Type
+	Service     reflect.Type
+}
+
+// Error implements the error interface
+func (err InvalidConstructorError) Error() string {
+	return fmt.Sprintf("%s is not a constructor for %s. Expected a func returning %s or (%s, error)", err.Constructor, err.Service, err.Service, err.Service)
+}
+
+// NewScope returns a child registry for shorter lived services, i.e. those belonging to a scene.
+// Lookups fall back to the parent, and services provided to the child hide the parent's. Close it when the scope ends
+func (services *Services) NewScope() *Services {
+	return &Services{parent: services}
+}
+
+// Close closes every service this scope built from a constructor that implements io.Closer, newest first.
+// The constructors stay registered, so a service looked up again after Close is built anew.
+// Values passed to Provide are owned by the caller and are not closed
+func (services *Services) Close() error {
+	services.mutex.Lock()
+	owned := services.owned
+	services.owned = nil
+	services.mutex.Unlock()
+
+	var errs []error
+	for i := len(owned) - 1; i >= 0; i-- {
+		entry := owned[i]
+		entry.mutex.Lock()
+		value := entry.value
+		entry.value, entry.built = reflect.Value{}, false
+		entry.mutex.Unlock()
+		if closer, ok := value.Interface().(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// services implements the ServiceScope interface
+func (services *Services) services() *Services {
+	return services
+}
+
+// services implements the ServiceScope interface, the application's scope is app-wide
+func (application *Application) services() *Services {
+	return application.Services
+}
+
+// Provide registers impl as the service for T in scope, replacing any earlier one. i.e. to swap a service for a fake in tests
+func Provide[T any](scope ServiceScope, impl T) {
+	value := reflect.New(typeOf[T]()).Elem()
+	value.Set(reflect.ValueOf(&impl).Elem())
+	scope.services().set(typeOf[T](), &service{value: value, built: true})
+}
+
+// ProvideConstructor registers a constructor for the service T in scope. It is called the first time T is looked up,
+// with each of its parameters looked up as a service too. constructor must be a func returning T or (T, error).
+// Constructors should take what they need as parameters: one calling Get for the service it is building waits on itself forever
+func ProvideConstructor[T any](scope ServiceScope, constructor any) error {
+	fn, err := checkConstructor(constructor, typeOf[T]())
+	if err != nil {
+		return err
+	}
+	scope.services().set(typeOf[T](), &service{constructor: fn})
+	return nil
+}
+
+// Get returns the service provided for T, looking through parent scopes. Returns a ServiceNotFoundError if there is none
+func Get[T any](scope ServiceScope) (T, error) {
+	var zero T
+	value, err := scope.services().resolve(typeOf[T]())
+	if err != nil {
+		return zero, err
+	}
+	impl, _ := value.Interface().(T)
+	return impl, nil
+}
+
+// MustGet is Get, panicking if the service can't be found. For services the engine can't run without
+func MustGet[T any](scope ServiceScope) T {
+	impl, err := Get[T](scope)
+	if err != nil {
+		panic(err)
+	}
+	return impl
+}
+
+// Construct calls constructor with each of its parameters looked up as a service, without registering the result.
+// Use it to inject services into modules, i.e. application.Use(app.MustConstruct[*Physics](application, NewPhysics))
+func Construct[T any](scope ServiceScope, constructor any) (T, error) {
+	var zero T
+	fn, err := checkConstructor(constructor, typeOf[T]())
+	if err != nil {
+		return zero, err
+	}
+	value, err := scope.services().call(fn)
+	if err != nil {
+		return zero, err
+	}
+	impl, _ := value.Interface().(T)
+	return impl, nil
+}
+
+// MustConstruct is Construct, panicking on failure
+func MustConstruct[T any](scope ServiceScope, constructor any) T {
+	impl, err := Construct[T](scope, constructor)
+	if err != nil {
+		panic(err)
+	}
+	return impl
+}
+
+// set stores a service entry
+func (services *Services) set(t reflect.Type, entry *service) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	if services.entries == nil {
+		services.entries = make(map[reflect.Type]*service)
+	}
+	services.entries[t] = entry
+}
+
+// resolve finds the service for t in this scope or its parents, building it if needed
+func (services *Services) resolve(t reflect.Type) (reflect.Value, error) {
+	scope, entry := services.lookup(t)
+	if entry == nil {
+		return reflect.Value{}, ServiceNotFoundError{Type: t}
+	}
+	return scope.build(entry, t)
+}
+
+// lookup finds the entry for t and the scope it was provided to, looking through parent scopes
+func (services *Services) lookup(t reflect.Type) (*Services, *service) {
+	for scope := services; scope != nil; scope = scope.parent {
+		scope.mutex.RLock()
+		entry, ok := scope.entries[t]
+		scope.mutex.RUnlock()
+		if ok {
+			return scope, entry
+		}
+	}
+	return nil, nil
+}
+
+// build returns the value of entry, constructing it in this scope the first time.
+// No lock is held while the constructor runs, so it can look up other services. Anyone else asking for
+// the same service meanwhile waits for it to be built
+func (services *Services) build(entry *service, t reflect.Type) (reflect.Value, error) {
+	if err := services.checkCycle(t, entry, nil); err != nil {
+		return reflect.Value{}, err
+	}
+	for {
+		entry.mutex.Lock()
+		if entry.built {
+			value := entry.value
+			entry.mutex.Unlock()
+			return value, nil
+		}
+		building := entry.building
+		if building == nil {
+			entry.building = make(chan struct{})
+			entry.mutex.Unlock()
+			break
+		}
+		entry.mutex.Unlock()
+		<-building
+	}
+
+	value, err := services.call(entry.constructor)
+	entry.mutex.Lock()
+	close(entry.building)
+	entry.building = nil
+	if err == nil {
+		entry.value, entry.built = value, true
+	}
+	entry.mutex.Unlock()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	services.mutex.Lock()
+	services.owned = append(services.owned, entry)
+	services.mutex.Unlock()
+	return value, nil
+}
+
+// checkCycle walks the constructors entry depends on without calling them, so a cycle is reported
+// up front rather than two goroutines waiting on each other's services forever.
+// path holds the services depending on this one
+func (services *Services) checkCycle(t reflect.Type, entry *service, path []reflect.Type) error {
+	for i, other := range path {
+		if other == t {
+			return ServiceCycleError{Cycle: append(append([]reflect.Type(nil), path[i:]...), t)}
+		}
+	}
+	entry.mutex.Lock()
+	built := entry.built
+	entry.mutex.Unlock()
+	if built {
+		return nil
+	}
+	path = append(path, t)
+	fn := entry.constructor.Type()
+	for i := 0; i < fn.NumIn(); i++ {
+		scope, dependency := services.lookup(fn.In(i))
+		if dependency == nil {
+			continue // Reported once the constructor is called
+		}
+		if err := scope.checkCycle(fn.In(i), dependency, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call invokes a checked constructor, looking up its parameters in this scope
+func (services *Services) call(fn reflect.Value) (reflect.Value, error) {
+	args := make([]reflect.Value, fn.Type().NumIn())
+	for i := range args {
+		arg, err := services.resolve(fn.Type().In(i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		args[i] = arg
+	}
+	results := fn.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		return reflect.Value{}, results[1].Interface().(error)
+	}
+	return results[0], nil
+}
+
+// checkConstructor makes sure constructor is a func returning t or (t, error)
+func checkConstructor(constructor any, t reflect.Type) (reflect.Value, error) {
+	fn := reflect.ValueOf(constructor)
+	errorType := typeOf[error]()
+	if fn.Kind() != reflect.Func || fn.IsNil() || fn.Type().IsVariadic() {
+		return fn, InvalidConstructorError{Constructor: reflect.TypeOf(constructor), Service: t}
+	}
+	out := fn.Type()
+	switch {
+	case out.NumOut() == 1 && out.Out(0) == t:
+	case out.NumOut() == 2 && out.Out(0) == t && out.Out(1) == errorType:
+	default:
+		return fn, InvalidConstructorError{Constructor: out, Service: t}
+	}
+	return fn, nil
+}
+
+// typeOf returns the reflect.Type of T, even when T is an interface
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+type testAssets struct{ root string }
+
+type testMixer struct {
+	assets *testAssets
+	name   string
+	log    *[]string
+}
+
+// Close implements the io.Closer interface
+func (mixer *testMixer) Close() error {
+	*mixer.log = append(*mixer.log, "close "+mixer.name)
+	return nil
+}
+
+type testPhysics struct{ mixer *testMixer }
+
+func TestServiceScopes(t *testing.T) {
+	var root Services
+	Provide(&root, &testAssets{root: "app"})
+	scene := root.NewScope()
+	if assets, err := Get[*testAssets](scene); err != nil || assets.root != "app" {
+		t.Fatalf("scope looked up %v, %v, want the parent's service", assets, err)
+	}
+
+	Provide(scene, &testAssets{root: "scene"})
+	if assets := MustGet[*testAssets](scene); assets.root != "scene" {
+		t.Errorf("scope looked up %s, want its own service hiding the parent's", assets.root)
+	}
+	if assets := MustGet[*testAssets](&root); assets.root != "app" {
+		t.Errorf("parent looked up %s, want its service untouched by the scope", assets.root)
+	}
+
+	var missing ServiceNotFoundError
+	if _, err := Get[*testMixer](scene); !errors.As(err, &missing) {
+		t.Errorf("looking up a service never provided returned %v, want a ServiceNotFoundError", err)
+	}
+}
+
+func TestServiceInjection(t *testing.T) {
+	var services Services
+	var log []string
+	Provide(&services, &testAssets{root: "app"})
+	built := 0
+	err := ProvideConstructor[*testMixer](&services, func(assets *testAssets) *testMixer {
+		built++
+		return &testMixer{assets: assets, name: "mixer", log: &log}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	physics, err := Construct[*testPhysics](&services, func(mixer *testMixer) (*testPhysics, error) {
+		return &testPhysics{mixer: mixer}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if physics.mixer.assets.root != "app" {
+		t.Error("constructor parameters were not injected")
+	}
+	if MustGet[*testMixer](&services) != physics.mixer || built != 1 {
+		t.Errorf("the mixer was built %d times, want once", built)
+	}
+	if _, err := Get[*testPhysics](&services); err == nil {
+		t.Error("Construct registered its result")
+	}
+
+	var invalid InvalidConstructorError
+	if err := ProvideConstructor[*testMixer](&services, func() *testAssets { return nil }); !errors.As(err, &invalid) {
+		t.Errorf("providing a constructor for the wrong type returned %v, want an InvalidConstructorError", err)
+	}
+}
+
+func TestServiceCycle(t *testing.T) {
+	var services Services
+	var log []string
+	_ = ProvideConstructor[*testMixer](&services, func(physics *testPhysics) *testMixer {
+		return &testMixer{name: "mixer", log: &log}
+	})
+	_ = ProvideConstructor[*testPhysics](&services, func(mixer *testMixer) *testPhysics { return &testPhysics{mixer: mixer} })
+
+	_, err := Get[*testPhysics](&services)
+	var cycle ServiceCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("looking up a service in a cycle returned %v, want a ServiceCycleError", err)
+	}
+	want := []string{"*app.testPhysics", "*app.testMixer", "*app.testPhysics"}
+	var got []string
+	for _, service := range cycle.Cycle {
+		got = append(got, service.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("cycle reported as %v, want %v", got, want)
+	}
+}
+
+func TestServiceConstructorLooksUpServices(t *testing.T) {
+	var services Services
+	var log []string
+	Provide(&services, &services)
+	Provide(&services, &testAssets{root: "app"})
+	_ = ProvideConstructor[*testMixer](&services, func(scope *Services) (*testMixer, error) {
+		// Looking services up by hand from a constructor must not wait on the registry
+		assets, err := Get[*testAssets](scope)
+		return &testMixer{assets: assets, name: "mixer", log: &log}, err
+	})
+	if mixer, err := Get[*testMixer](&services); err != nil || mixer.assets.root != "app" {
+		t.Errorf("constructor looking up a service returned %v, %v", mixer, err)
+	}
+}
+
+func TestServiceConcurrentLookup(t *testing.T) {
+	var services Services
+	var log []string
+	var mutex sync.Mutex
+	built := 0
+	_ = ProvideConstructor[*testMixer](&services, func() *testMixer {
+		mutex.Lock()
+		built++
+		mutex.Unlock()
+		return &testMixer{name: "mixer", log: &log}
+	})
+
+	var wait sync.WaitGroup
+	mixers := make([]*testMixer, 8)
+	for i := range mixers {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			mixers[i] = MustGet[*testMixer](&services)
+		}()
+	}
+	wait.Wait()
+	if built != 1 {
+		t.Errorf("the mixer was built %d times, want once", built)
+	}
+	for _, mixer := range mixers {
+		if mixer != mixers[0] {
+			t.Fatal("concurrent lookups got different mixers")
+		}
+	}
+}
+
+func TestServiceCloseOrder(t *testing.T) {
+	var services Services
+	var log []string
+	Provide(&services, &testMixer{name: "provided", log: &log})
+	scope := services.NewScope()
+	_ = ProvideConstructor[*testAssets](scope, func() *testAssets { return &testAssets{} })
+	_ = ProvideConstructor[*testPhysics](scope, func(assets *testAssets) *testPhysics {
+		return &testPhysics{mixer: &testMixer{name: "physics", log: &log}}
+	})
+	type music struct{ *testMixer }
+	_ = ProvideConstructor[music](scope, func(physics *testPhysics) music {
+		return music{&testMixer{name: "music", log: &log}}
+	})
+	_ = ProvideConstructor[*testMixer](scope, func(music music) *testMixer {
+		return &testMixer{name: "mixer", log: &log}
+	})
+	MustGet[*testMixer](scope)
+
+	if err := scope.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// physics isn't a Closer, provided values belong to the caller
+	if want := []string{"close mixer", "close music"}; !slices.Equal(log, want) {
+		t.Errorf("services closed as %v, want %v", log, want)
+	}
+
+	log = nil
+	MustGet[*testMixer](scope)
+	if err := scope.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"close mixer", "close music"}; !slices.Equal(log, want) {
+		t.Errorf("services looked up after Close closed as %v, want them built anew", log)
+	}
+}
+
+func TestRunClosesServices(t *testing.T) {
+	var log []string
+	application := New("test", "1.0.0")
+	application.Options.Context = &testContext{new(win.NullWindow)}
+	application.Options.ExitWhen = func(*Application) bool { return true }
+	_ = ProvideConstructor[*testMixer](application, func(*Application) *testMixer {
+		return &testMixer{name: "mixer", log: &log}
+	})
+	if err := application.Use(&serviceModule{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := application.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(log, []string{"close mixer"}) {
+		t.Errorf("services closed as %v when Run returned, want the mixer closed", log)
+	}
+}
+
+// serviceModule looks up the mixer on Init, so it is built while the application runs
+type serviceModule struct{}
+
+func (module *serviceModule) Name() string           { return "services" }
+func (module *serviceModule) Dependencies() []string { return nil }
+
+func (module *serviceModule) Init(application *Application) error {
+	_, err := Get[*testMixer](application)
+	return err
+}
+
+func (module *serviceModule) Shutdown(*Application) error { return nil }
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/gjh33/SurrealEngine/graphics/win"
+
+	gfx "github.com/gjh33/SurrealEngine/graphics"
+)
+
+// Settings are the engine settings Application.Config loads on Run, i.e. from a file, -window.title=Game or an environment variable
+// named after the application such as MYGAME_GRAPHICS_VSYNC=1, see NewConfig
+type Settings struct {
+	Window   win.Settings `json:"window"`
+	Graphics gfx.Settings `json:"graphics"`
+}
+
+// DefaultSettings returns the settings an application starts with before any config is loaded
+func DefaultSettings(name string) Settings {
+	return Settings{
+		Window: win.Settings{
+			Title:     name,
+			Resizable: true,
+			Decorated: true,
+		},
+	}
+}
+
+// Validate implements the ConfigValidator interface
+func (settings *Settings) Validate() error {
+	graphics := settings.Graphics
+	switch {
+	case graphics.DisplayResolution.Width < 0 || graphics.DisplayResolution.Height < 0:
+		return ConfigError{Key: "graphics.DisplayResolution", Err: errors.New("must not be negative")}
+	case graphics.TargetResolution.Width < 0 || graphics.TargetResolution.Height < 0:
+		return ConfigError{Key: "graphics.TargetResolution", Err: errors.New("must not be negative")}
+	case graphics.OutputResolution.Width < 0 || graphics.OutputResolution.Height < 0:
+		return ConfigError{Key: "graphics.OutputResolution", Err: errors.New("must not be negative")}
+	case graphics.DisplayMode < gfx.Windowed || graphics.DisplayMode > gfx.Fullscreen:
+		return ConfigError{Key: "graphics.DisplayMode", Err: errors.New("must be 0 (windowed), 1 (borderless window) or 2 (fullscreen)")}
+	case graphics.VSync < gfx.NoSync || graphics.VSync > gfx.TripleBuffered:
+		return ConfigError{Key: "graphics.VSync", Err: errors.New("must be 0 (off), 1 (double buffered) or 2 (triple buffered)")}
+	case graphics.AntiAliasing < gfx.None || graphics.AntiAliasing > gfx.MSAA:
+		return ConfigError{Key: "graphics.AntiAliasing", Err: errors.New("must be 0 (none) or 1 (MSAA)")}
+	case graphics.MSAASamples < gfx.Sample2X || graphics.MSAASamples > gfx.Sample32X:
+		return ConfigError{Key: "graphics.MSAASamples", Err: errors.New("must be between 0 (2x) and 4 (32x)")}
+	}
+	return nil
+}
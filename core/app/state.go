@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// State is a stage of the application's life cycle
+type State int32
+
+const (
+	StateCreated  State = iota // Built by New, Run has not been called yet
+	StateStarting              // Run was called, subsystems and the window are being initialized
+	StateRunning               // In the update loop
+	StatePaused                // In the update loop, but updates are not sent out. i.e. while the window is iconified
+	StateQuitting              // The update loop has ended and the application is shutting down
+	StateStopped               // Run has returned. Run may be called again
+)
+
+// transitions lists the states each state may move to
+var transitions = map[State][]State{
+	StateCreated:  {StateStarting},
+	StateStarting: {StateRunning, StateQuitting},
+	StateRunning:  {StatePaused, StateQuitting},
+	StatePaused:   {StateRunning, StateQuitting},
+	StateQuitting: {StateStopped},
+	StateStopped:  {StateStarting},
+}
+
+// String implements the fmt.Stringer interface
+func (state State) String() string {
+	switch state {
+	case StateCreated:
+		return "Created"
+	case StateStarting:
+		return "Starting"
+	case StateRunning:
+		return "Running"
+	case StatePaused:
+		return "Paused"
+	case StateQuitting:
+		return "Quitting"
+	case StateStopped:
+		return "Stopped"
+	}
+	return fmt.Sprintf("State(%d)", int32(state))
+}
+
+// CanTransition returns if the life cycle allows moving from state to next
+func (state State) CanTransition(next State) bool {
+	for _, allowed := range transitions[state] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidTransitionError is the error returned when the application is asked to move to a state it can't reach from its current one
+type InvalidTransitionError struct {
+	From State
+	To   State
+}
+
+// Error implements the error interface
+func (err InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid application state transition from %s to %s", err.From, err.To)
+}
+
+// StateUnreachableError is the error returned by WaitFor when the application stops before reaching the state
+type StateUnreachableError struct {
+	State State
+}
+
+// Error implements the error interface
+func (err StateUnreachableError) Error() string {
+	return fmt.Sprintf("application stopped before reaching state %s", err.State)
+}
+
+// stateMachine tracks the application's State and who is waiting on it. The zero value starts in StateCreated
+type stateMachine struct {
+	mutex   sync.Mutex
+	current State
+	waiters map[State][]chan error
+}
+
+// get returns the current state
+func (machine *stateMachine) get() State {
+	machine.mutex.Lock()
+	defer machine.mutex.Unlock()
+	return machine.current
+}
+
+// set moves to next, waking anyone waiting for it. It returns the previous state
+func (machine *stateMachine) set(next State) (State, error) {
+	machine.mutex.Lock()
+	defer machine.mutex.Unlock()
+	previous := machine.current
+	if !previous.CanTransition(next) {
+		return previous, InvalidTransitionError{From: previous, To: next}
+	}
+	machine.current = next
+	for _, waiter := range machine.waiters[next] {
+		waiter <- nil
+	}
+	delete(machine.waiters, next)
+	if next == StateStopped {
+		// Nothing else will be reached this run
+		for state, waiters := range machine.waiters {
+			for _, waiter := range waiters {
+				waiter <- StateUnreachableError{State: state}
+			}
+		}
+		machine.waiters = nil
+	}
+	return previous, nil
+}
+
+// wait blocks until the machine enters state
+func (machine *stateMachine) wait(ctx context.Context, state State) error {
+	machine.mutex.Lock()
+	if machine.current == state {
+		machine.mutex.Unlock()
+		return nil
+	}
+	waiter := make(chan error, 1)
+	if machine.waiters == nil {
+		machine.waiters = make(map[State][]chan error)
+	}
+	machine.waiters[state] = append(machine.waiters[state], waiter)
+	machine.mutex.Unlock()
+
+	select {
+	case err := <-waiter:
+		return err
+	case <-ctx.Done():
+		machine.mutex.Lock()
+		defer machine.mutex.Unlock()
+		waiters := machine.waiters[state]
+		for i, other := range waiters {
+			if other == waiter {
+				machine.waiters[state] = append(waiters[:i:i], waiters[i+1:]...)
+				break
+			}
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for an application. Use a ManualClock in tests to step time deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock reading the operating system's time
+type SystemClock struct{}
+
+// Now implements the Clock interface
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a Clock that only moves when told to. The zero value starts at the zero time.
+type ManualClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// Now implements the Clock interface
+func (clock *ManualClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+// Advance moves the clock forward
+func (clock *ManualClock) Advance(d time.Duration) {
+	clock.mutex.Lock()
+	clock.now = clock.now.Add(d)
+	clock.mutex.Unlock()
+}
+
+// Set moves the clock to a specific time
+func (clock *ManualClock) Set(now time.Time) {
+	clock.mutex.Lock()
+	clock.now = now
+	clock.mutex.Unlock()
+}
+
+// Default settings for Time
+const (
+	DefaultFixedStep     = time.Second / 50
+	DefaultMaxFixedSteps = 5
+)
+
+// Time keeps track of frame timing for an application. Call Tick once at the start of every frame.
+type Time struct {
+	Clock         Clock         // Where time comes from
+	Scale         float64       // Multiplier applied to scaled time. 0 pauses gameplay, 1 is real time
+	FixedStep     time.Duration // Time simulated by each fixed update. Fixed updates follow scaled time
+	MaxFixedSteps int           // Most fixed updates run in a single frame. Stops a slow frame from causing ever slower frames
+
+	started         bool
+	last            time.Time
+	unscaledDelta   time.Duration
+	delta           time.Duration
+	unscaledElapsed time.Duration
+	elapsed         time.Duration
+	accumulator     time.Duration
+	frameCount      uint64
+	fixedCount      uint64
+}
+
+// NewTime creates a Time driven by clock, with the default fixed step
+func NewTime(clock Clock) *Time {
+	return &Time{Clock: clock, Scale: 1, FixedStep: DefaultFixedStep, MaxFixedSteps: DefaultMaxFixedSteps}
+}
+
+// Tick starts a new frame, measuring how long the last one took.
+// Returns how many fixed updates should run this frame.
+func (t *Time) Tick() int {
+	now := t.Clock.Now()
+	t.frameCount++
+	if !t.started {
+		// There is no previous frame to measure the first one against
+		t.started = true
+		t.last = now
+		return 0
+	}
+
+	t.unscaledDelta = now.Sub(t.last)
+	if t.unscaledDelta < 0 {
+		t.unscaledDelta = 0
+	}
+	t.last = now
+	t.delta = time.Duration(float64(t.unscaledDelta) * t.Scale)
+	t.unscaledElapsed += t.unscaledDelta
+	t.elapsed += t.delta
+
+	if t.FixedStep <= 0 {
+		return 0
+	}
+	t.accumulator += t.delta
+	steps := int(t.accumulator / t.FixedStep)
+	if t.MaxFixedSteps > 0 && steps > t.MaxFixedSteps {
+		// We can't catch up, drop the backlog rather than fall further and further behind
+		steps = t.MaxFixedSteps
+		t.accumulator = 0
+	} else {
+		t.accumulator -= time.Duration(steps) * t.FixedStep
+	}
+	t.fixedCount += uint64(steps)
+	return steps
+}
+
+// Delta returns the scaled time the last frame took
+func (t *Time) Delta() time.Duration {
+	return t.delta
+}
+
+// DeltaSeconds returns Delta in seconds, which is usually what gameplay math wants
+func (t *Time) DeltaSeconds() float64 {
+	return t.delta.Seconds()
+}
+
+// UnscaledDelta returns the real time the last frame took, ignoring Scale
+func (t *Time) UnscaledDelta() time.Duration {
+	return t.unscaledDelta
+}
+
+// Elapsed returns the scaled time since the first frame
+func (t *Time) Elapsed() time.Duration {
+	return t.elapsed
+}
+
+// UnscaledElapsed returns the real time since the first frame
+func (t *Time) UnscaledElapsed() time.Duration {
+	return t.unscaledElapsed
+}
+
+// FrameCount returns the number of the current frame. Frames are counted from 1, 0 is before the first frame
+func (t *Time) FrameCount() uint64 {
+	return t.frameCount
+}
+
+// FixedCount returns the number of fixed updates run so far
+func (t *Time) FixedCount() uint64 {
+	return t.fixedCount
+}
+
+// FixedAlpha returns how far we are between the last fixed update and the next, from 0 to 1.
+// Use it to interpolate what is rendered between fixed update states.
+func (t *Time) FixedAlpha() float64 {
+	if t.FixedStep <= 0 {
+		return 0
+	}
+	return float64(t.accumulator) / float64(t.FixedStep)
+}
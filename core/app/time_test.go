@@ -0,0 +1,116 @@
+package app
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// newTestTime returns a Time on a manual clock that has already had its first frame
+func newTestTime() (*Time, *ManualClock) {
+	clock := new(ManualClock)
+	t := NewTime(clock)
+	t.Tick()
+	return t, clock
+}
+
+func TestTimeFirstTick(t *testing.T) {
+	clock := new(ManualClock)
+	clock.Advance(time.Hour)
+	tm := NewTime(clock)
+	if steps := tm.Tick(); steps != 0 {
+		t.Errorf("first Tick returned %d fixed steps, want 0", steps)
+	}
+	if tm.Delta() != 0 || tm.FrameCount() != 1 {
+		t.Errorf("first frame has delta %s and number %d, want 0 and 1", tm.Delta(), tm.FrameCount())
+	}
+}
+
+func TestTimeDelta(t *testing.T) {
+	tm, clock := newTestTime()
+	clock.Advance(16 * time.Millisecond)
+	tm.Tick()
+	if tm.Delta() != 16*time.Millisecond || tm.UnscaledDelta() != 16*time.Millisecond {
+		t.Errorf("delta is %s (unscaled %s), want 16ms", tm.Delta(), tm.UnscaledDelta())
+	}
+
+	tm.Scale = 0.5
+	clock.Advance(20 * time.Millisecond)
+	tm.Tick()
+	if tm.Delta() != 10*time.Millisecond || tm.UnscaledDelta() != 20*time.Millisecond {
+		t.Errorf("half speed delta is %s (unscaled %s), want 10ms (20ms)", tm.Delta(), tm.UnscaledDelta())
+	}
+	if tm.Elapsed() != 26*time.Millisecond || tm.UnscaledElapsed() != 36*time.Millisecond {
+		t.Errorf("elapsed is %s (unscaled %s), want 26ms (36ms)", tm.Elapsed(), tm.UnscaledElapsed())
+	}
+
+	// A clock going backwards must not produce negative frames
+	clock.Advance(-time.Second)
+	tm.Tick()
+	if tm.Delta() != 0 {
+		t.Errorf("delta after the clock went backwards is %s, want 0", tm.Delta())
+	}
+}
+
+func TestTimeFixedSteps(t *testing.T) {
+	tm, clock := newTestTime()
+	tm.FixedStep = 10 * time.Millisecond
+
+	total := 0
+	for _, test := range []struct {
+		advance time.Duration
+		steps   int
+	}{
+		{4 * time.Millisecond, 0},
+		{4 * time.Millisecond, 0},
+		{4 * time.Millisecond, 1},  // 12ms accumulated
+		{18 * time.Millisecond, 2}, // 20ms accumulated
+		{10 * time.Millisecond, 1},
+	} {
+		clock.Advance(test.advance)
+		steps := tm.Tick()
+		if steps != test.steps {
+			t.Errorf("frame %d ran %d fixed steps, want %d", tm.FrameCount(), steps, test.steps)
+		}
+		total += steps
+	}
+	if tm.FixedCount() != uint64(total) {
+		t.Errorf("FixedCount is %d, want %d", tm.FixedCount(), total)
+	}
+}
+
+func TestTimeFixedAlpha(t *testing.T) {
+	tm, clock := newTestTime()
+	tm.FixedStep = 20 * time.Millisecond
+
+	clock.Advance(25 * time.Millisecond)
+	tm.Tick()
+	if alpha := tm.FixedAlpha(); math.Abs(alpha-0.25) > 1e-9 {
+		t.Errorf("alpha is %f, want 0.25", alpha)
+	}
+	clock.Advance(10 * time.Millisecond)
+	tm.Tick()
+	if alpha := tm.FixedAlpha(); math.Abs(alpha-0.75) > 1e-9 {
+		t.Errorf("alpha is %f, want 0.75", alpha)
+	}
+}
+
+func TestTimeSpiralOfDeathClamp(t *testing.T) {
+	tm, clock := newTestTime()
+	tm.FixedStep = 10 * time.Millisecond
+	tm.MaxFixedSteps = 3
+
+	clock.Advance(time.Second)
+	if steps := tm.Tick(); steps != 3 {
+		t.Errorf("a one second hitch ran %d fixed steps, want the maximum of 3", steps)
+	}
+	if tm.FixedAlpha() != 0 {
+		t.Errorf("the backlog was kept, alpha is %f", tm.FixedAlpha())
+	}
+
+	// Back to normal frames, the dropped backlog must not come back
+	clock.Advance(10 * time.Millisecond)
+	if steps := tm.Tick(); steps != 1 {
+		t.Errorf("the frame after the hitch ran %d fixed steps, want 1", steps)
+	}
+}
@@ -0,0 +1,253 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionRange is a set of constraints on a SemanticVersion, i.e. "^1.2", ">=1.0 <2.0" or "1.x || >=3.0.0-beta".
+//
+// Space separated comparators must all match, and any of the sets separated by "||" may match. Supported are
+// =, >, >=, <, <=, ~ (patch updates), ^ (updates that don't change the left most non zero part), "A - B" hyphen ranges,
+// and partial versions or wildcards like 1.2, 1.x and *, which match every version they leave open.
+// Pre-releases are matched by precedence like any other version, so ">=1.0.0" does not match 1.0.0-beta but "<2.0" does not match 2.0.0-beta either.
+type VersionRange struct {
+	source string
+	sets   [][]versionComparator
+}
+
+// versionComparator is a single comparison against a version
+type versionComparator struct {
+	operator string // One of =, >, >=, <, <=
+	version  SemanticVersion
+}
+
+// partialVersion is a version that may leave parts open, i.e. 1.2 or 1.x
+type partialVersion struct {
+	SemanticVersion
+	parts int // How many of MajorRelease, MinorRelease and Patch were given
+}
+
+// ParseVersionRange parses a range of versions, see VersionRange
+func ParseVersionRange(str string) (VersionRange, error) {
+	versionRange := VersionRange{}
+	err := versionRange.Parse(str)
+	return versionRange, err
+}
+
+// Parse parses the range from a string, see VersionRange
+func (versionRange *VersionRange) Parse(str string) error {
+	parsed := VersionRange{source: strings.TrimSpace(str)}
+	for _, set := range strings.Split(str, "||") {
+		comparators, err := parseComparatorSet(set)
+		if err != nil {
+			return fmt.Errorf("failed to parse version range \"%s\".\n %s", str, err.Error())
+		}
+		parsed.sets = append(parsed.sets, comparators)
+	}
+	*versionRange = parsed
+	return nil
+}
+
+// Contains returns if version is in the range
+func (versionRange VersionRange) Contains(version SemanticVersion) bool {
+	for _, set := range versionRange.sets {
+		matched := true
+		for _, comparator := range set {
+			if !comparator.matches(version) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the range as it was parsed
+func (versionRange VersionRange) String() string {
+	return versionRange.source
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (versionRange VersionRange) MarshalText() ([]byte, error) {
+	return []byte(versionRange.source), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (versionRange *VersionRange) UnmarshalText(text []byte) error {
+	return versionRange.Parse(string(text))
+}
+
+// Satisfies returns if the version is in the range
+func (version SemanticVersion) Satisfies(versionRange VersionRange) bool {
+	return versionRange.Contains(version)
+}
+
+// matches returns if version passes the comparison
+func (comparator versionComparator) matches(version SemanticVersion) bool {
+	c := version.Compare(comparator.version)
+	switch comparator.operator {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return c == 0
+}
+
+// parseComparatorSet parses space separated comparators, or a hyphen range
+func parseComparatorSet(set string) ([]versionComparator, error) {
+	fields := strings.Fields(set)
+	if len(fields) == 3 && fields[1] == "-" {
+		from, err := parsePartialVersion(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		to, err := parsePartialVersion(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		return append(desugar(">=", from), desugar("<=", to)...), nil
+	}
+
+	comparators := []versionComparator{}
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		operator := ""
+		for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+			if strings.HasPrefix(field, candidate) {
+				operator = candidate
+				field = strings.TrimPrefix(field, candidate)
+				break
+			}
+		}
+		// Allow a space between the operator and the version, i.e. ">= 1.0"
+		if field == "" && operator != "" && i+1 < len(fields) {
+			i++
+			field = fields[i]
+		}
+		version, err := parsePartialVersion(field)
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, desugar(operator, version)...)
+	}
+	return comparators, nil
+}
+
+// parsePartialVersion parses a version that may leave parts open with x, X or *, or leave them out entirely
+func parsePartialVersion(str string) (partialVersion, error) {
+	partial := partialVersion{}
+	core := strings.TrimPrefix(str, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		// Pre-releases and build metadata only make sense on a full version
+		version, err := ParseVersion(core)
+		if err != nil {
+			return partial, err
+		}
+		return partialVersion{version, 3}, nil
+	}
+	numbers := []*int{&partial.MajorRelease, &partial.MinorRelease, &partial.Patch}
+	substrs := strings.Split(core, ".")
+	if len(substrs) > 3 {
+		return partial, fmt.Errorf("\"%s\" has too many parts", str)
+	}
+	for i, substr := range substrs {
+		if substr == "x" || substr == "X" || substr == "*" {
+			break
+		}
+		number, overflow, err := parseVersionNumber(substr)
+		if err != nil {
+			return partial, fmt.Errorf("invalid version \"%s\".\n %s", str, err.Error())
+		}
+		*numbers[i], partial.overflow[i] = number, overflow
+		partial.parts++
+	}
+	return partial, nil
+}
+
+// desugar turns an operator on a partial version into plain comparisons
+func desugar(operator string, partial partialVersion) []versionComparator {
+	version := partial.SemanticVersion
+	// next returns the lowest version above everything matching the first parts given, i.e. next(2) of 1.2 is 1.3.0-0
+	next := func(parts int) SemanticVersion {
+		bumped := SemanticVersion{MajorRelease: version.MajorRelease, MinorRelease: version.MinorRelease, Patch: version.Patch, PreRelease: "0"}
+		bumped.overflow = version.overflow
+		numbers := []*int{&bumped.MajorRelease, &bumped.MinorRelease, &bumped.Patch}
+		for i := parts; i < 3; i++ {
+			*numbers[i], bumped.overflow[i] = 0, ""
+		}
+		// Numbers can be larger than an int, so add in decimal
+		*numbers[parts-1], bumped.overflow[parts-1], _ = parseVersionNumber(incrementDecimal(version.number(parts - 1)))
+		return bumped
+	}
+	none := []versionComparator{{"<", SemanticVersion{PreRelease: "0"}}}
+	if partial.parts == 0 {
+		switch operator {
+		case ">", "<":
+			return none
+		}
+		return nil // Any version
+	}
+
+	switch operator {
+	case ">":
+		if partial.parts == 3 {
+			return []versionComparator{{">", version}}
+		}
+		return []versionComparator{{">=", next(partial.parts)}}
+	case ">=":
+		return []versionComparator{{">=", version}}
+	case "<":
+		if partial.parts == 3 {
+			return []versionComparator{{"<", version}}
+		}
+		version.PreRelease = "0"
+		return []versionComparator{{"<", version}}
+	case "<=":
+		if partial.parts == 3 {
+			return []versionComparator{{"<=", version}}
+		}
+		return []versionComparator{{"<", next(partial.parts)}}
+	case "~":
+		parts := partial.parts
+		if parts == 3 {
+			parts = 2
+		}
+		return []versionComparator{{">=", version}, {"<", next(parts)}}
+	case "^":
+		parts := 1
+		switch {
+		case version.MajorRelease > 0 || partial.parts == 1:
+		case version.MinorRelease > 0 || partial.parts == 2:
+			parts = 2
+		default:
+			parts = 3
+		}
+		return []versionComparator{{">=", version}, {"<", next(parts)}}
+	}
+	if partial.parts == 3 {
+		return []versionComparator{{"=", version}}
+	}
+	return []versionComparator{{">=", version}, {"<", next(partial.parts)}}
+}
+
+// incrementDecimal adds one to a number written in decimal digits
+func incrementDecimal(digits string) string {
+	bytes := []byte(digits)
+	for i := len(bytes) - 1; i >= 0; i-- {
+		if bytes[i] != '9' {
+			bytes[i]++
+			return string(bytes)
+		}
+		bytes[i] = '0'
+	}
+	return "1" + string(bytes)
+}
@@ -0,0 +1,245 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+)
+
+// Bridge forwards events between a local bus and a remote process, i.e. a level editor, telemetry dashboard or test harness.
+// Every registered event delivered on the local bus is sent to the remote, and every event received from the remote is
+// delivered on the local bus. Events are sent as JSON, one per line, so any stream works: a TCP connection, a websocket
+// or a pipe. Both sides need the same types registered under the same names.
+// Events are written from a goroutine of their own, so a slow remote never holds up the local bus.
+type Bridge struct {
+	Incoming *Queue           // When set, received events are posted here instead of being dispatched from the bridge's goroutine
+	Filter   func(Event) bool // When set, only local events it accepts are forwarded
+
+	local     *Bus
+	registry  *Registry
+	conn      io.ReadWriteCloser
+	outgoing  chan bridgeMessage // Waiting to be written by the writer goroutine
+	closed    chan struct{}
+	forwarder *handler // Observes the local bus. Skipped when delivering remote events, so they aren't echoed back
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// BridgeBacklog is how many events may wait to be written to a remote. Once it is full the remote is considered stalled
+const BridgeBacklog = 1024
+
+// BridgeStalledError is the error returned when a remote stopped reading and the backlog of events to send filled up.
+// The bridge is closed, as the remote missed events it can't catch up on
+type BridgeStalledError struct{}
+
+// Error implements the error interface
+func (err *BridgeStalledError) Error() string {
+	return fmt.Sprintf("bridge remote stalled with %d events waiting to be sent", BridgeBacklog)
+}
+
+// bridgeMessage is a single event on the wire
+type bridgeMessage struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// NewBridge creates a bridge between a local bus and a connection to a remote process. Call Run to start it,
+// nothing is read or written before then. Events sent earlier wait in the backlog
+func NewBridge(conn io.ReadWriteCloser, local *Bus, registry *Registry) *Bridge {
+	bridge := &Bridge{local: local, registry: registry, conn: conn}
+	bridge.outgoing = make(chan bridgeMessage, BridgeBacklog)
+	bridge.closed = make(chan struct{})
+	bridge.forwarder = &handler{listener: "event.Bridge", priority: DefaultPriority, call: func(e Event) error {
+		bridge.forward(e)
+		return nil
+	}}
+	return bridge
+}
+
+// DialBridge connects to a bridge server, i.e. DialBridge("tcp", "localhost:7777", application.Bus(), registry)
+func DialBridge(network string, address string, local *Bus, registry *Registry) (*Bridge, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return NewBridge(conn, local, registry), nil
+}
+
+// Run forwards events in both directions until the connection is closed. Closing the bridge is not considered an error.
+// The writer goroutine lives as long as Run, so a bridge that is never run leaks nothing
+func (bridge *Bridge) Run() error {
+	go bridge.write()
+	forwarding := bridge.local.addHandler(anyEvent, bridge.forwarder)
+	defer forwarding.Cancel()
+
+	scanner := bufio.NewScanner(bridge.conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var message bridgeMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			_ = bridge.Close()
+			return fmt.Errorf("failed to read bridge message.\n Unmarshal Error: %s", err.Error())
+		}
+		e, err := bridge.registry.Decode(message.Type, message.Event)
+		if err != nil {
+			// The remote may know more events than we do, that's fine
+			var unregistered *UnregisteredEventError
+			if errors.As(err, &unregistered) {
+				continue
+			}
+			_ = bridge.Close()
+			return err
+		}
+		if bridge.Incoming != nil {
+			bridge.Incoming.post(func() error { return bridge.receive(e) })
+		} else if err := bridge.receive(e); err != nil {
+			_ = bridge.Close()
+			return err
+		}
+	}
+
+	// Whatever the connection reports after a Close, i.e. net.ErrClosed or io.ErrClosedPipe, is not an error
+	select {
+	case <-bridge.closed:
+		return nil
+	default:
+	}
+	err := scanner.Err()
+	_ = bridge.Close()
+	return err
+}
+
+// Send queues an event to be forwarded to the remote directly, without publishing it locally.
+// It never waits on the remote: if the backlog is full the bridge is closed and a BridgeStalledError returned
+func (bridge *Bridge) Send(e Event) error {
+	name, payload, err := bridge.registry.Encode(e)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-bridge.closed:
+		return net.ErrClosed
+	default:
+	}
+	select {
+	case bridge.outgoing <- bridgeMessage{Type: name, Event: payload}:
+		return nil
+	default:
+		_ = bridge.Close()
+		return &BridgeStalledError{}
+	}
+}
+
+// Close closes the connection, which makes Run return. Events still waiting to be sent are dropped
+func (bridge *Bridge) Close() error {
+	bridge.closeOnce.Do(func() {
+		close(bridge.closed)
+		bridge.closeErr = bridge.conn.Close()
+	})
+	return bridge.closeErr
+}
+
+// write sends queued events to the remote until the bridge is closed
+func (bridge *Bridge) write() {
+	encoder := json.NewEncoder(bridge.conn)
+	for {
+		select {
+		case message := <-bridge.outgoing:
+			if err := encoder.Encode(message); err != nil {
+				_ = bridge.Close()
+				return
+			}
+		case <-bridge.closed:
+			return
+		}
+	}
+}
+
+// forward sends a local event to the remote
+func (bridge *Bridge) forward(e Event) {
+	if _, ok := bridge.registry.Name(e); !ok {
+		return
+	}
+	if bridge.Filter != nil && !bridge.Filter(e) {
+		return
+	}
+	if err := bridge.Send(e); err != nil {
+		_ = bridge.Close()
+	}
+}
+
+// receive delivers a remote event on the local bus, without forwarding it back
+func (bridge *Bridge) receive(e Event) error {
+	_, err := bridge.local.deliver(reflect.TypeOf(e), e, bridge.forwarder)
+	return err
+}
+
+// BridgeServer accepts bridges from remote processes, connecting each of them to the same local bus
+type BridgeServer struct {
+	Incoming *Queue           // Passed on to every accepted bridge, see Bridge
+	Filter   func(Event) bool // Passed on to every accepted bridge, see Bridge
+	OnError  func(error)      // Called when an accepted bridge fails
+
+	local    *Bus
+	registry *Registry
+	mutex    sync.Mutex
+	listener net.Listener
+	bridges  map[*Bridge]struct{}
+}
+
+// NewBridgeServer creates a server for remote processes to bridge onto a local bus
+func NewBridgeServer(local *Bus, registry *Registry) *BridgeServer {
+	return &BridgeServer{local: local, registry: registry, bridges: make(map[*Bridge]struct{})}
+}
+
+// Serve accepts connections until the listener is closed, running a bridge for each of them
+func (server *BridgeServer) Serve(listener net.Listener) error {
+	server.mutex.Lock()
+	server.listener = listener
+	server.mutex.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		bridge := NewBridge(conn, server.local, server.registry)
+		bridge.Incoming = server.Incoming
+		bridge.Filter = server.Filter
+		server.mutex.Lock()
+		server.bridges[bridge] = struct{}{}
+		server.mutex.Unlock()
+
+		go func() {
+			err := bridge.Run()
+			server.mutex.Lock()
+			delete(server.bridges, bridge)
+			server.mutex.Unlock()
+			if err != nil && server.OnError != nil {
+				server.OnError(err)
+			}
+		}()
+	}
+}
+
+// Close stops accepting connections and closes every bridge
+func (server *BridgeServer) Close() error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var err error
+	if server.listener != nil {
+		err = server.listener.Close()
+	}
+	for bridge := range server.bridges {
+		_ = bridge.Close()
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package event
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type bridgePing struct{ N int }
+
+type bridgePong struct{ N int }
+
+func bridgeRegistry() *Registry {
+	registry := new(Registry)
+	Register[bridgePing](registry, "event.bridgePing")
+	Register[bridgePong](registry, "event.bridgePong")
+	return registry
+}
+
+// receive waits for a value, failing the test if none arrives in time
+func receive[T any](t *testing.T, values <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-values:
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		var zero T
+		return zero
+	}
+}
+
+func TestBridgeLoopback(t *testing.T) {
+	registry := bridgeRegistry()
+	var server, client Bus
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("loopback unavailable: %s", err.Error())
+	}
+	bridgeServer := NewBridgeServer(&server, registry)
+	served := make(chan error, 1)
+	go func() { served <- bridgeServer.Serve(listener) }()
+
+	connected := make(chan struct{}, 1)
+	defer Subscribe(&server, func(e bridgePing) {
+		if e.N == 0 {
+			connected <- struct{}{}
+			return
+		}
+		_, _ = Publish(&server, bridgePong{N: e.N + 1})
+	}).Cancel()
+	pongs := make(chan int, 1)
+	defer Subscribe(&client, func(e bridgePong) { pongs <- e.N }).Cancel()
+	echoes := make(chan int, 1)
+	defer Subscribe(&client, func(e bridgePing) { echoes <- e.N }).Cancel()
+
+	bridge, err := DialBridge("tcp", listener.Addr().String(), &client, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ran := make(chan error, 1)
+	go func() { ran <- bridge.Run() }()
+
+	// The server only forwards once it accepted the connection, so wait for a first event to make it across
+	if err := bridge.Send(bridgePing{N: 0}); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, connected)
+
+	if _, err := Publish(&client, bridgePing{N: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if n := receive(t, echoes); n != 1 {
+		t.Errorf("local ping delivered with N = %d, want 1", n)
+	}
+	if n := receive(t, pongs); n != 2 {
+		t.Errorf("pong from the server has N = %d, want 2", n)
+	}
+	select {
+	case n := <-echoes:
+		t.Errorf("ping %d was echoed back to the client", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := bridgeServer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := receive(t, served); err != nil {
+		t.Errorf("Serve returned %v after Close", err)
+	}
+	if err := receive(t, ran); err != nil {
+		t.Errorf("Run returned %v after the server closed", err)
+	}
+}
+
+func TestBridgeStalledRemote(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	var bus Bus
+	bridge := NewBridge(local, &bus, bridgeRegistry())
+	go func() { _ = bridge.Run() }()
+
+	// Nobody reads the remote end, so the first write blocks and the backlog fills up
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i <= BridgeBacklog+1; i++ {
+			if err := bridge.Send(bridgePing{N: i}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	var stalled *BridgeStalledError
+	if err := receive(t, done); !errors.As(err, &stalled) {
+		t.Fatalf("Send returned %v, want a BridgeStalledError", err)
+	}
+	if err := bridge.Send(bridgePing{}); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Send on a stalled bridge returned %v, want net.ErrClosed", err)
+	}
+}
+
+func TestBridgeSendBeforeRun(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	var bus Bus
+	bridge := NewBridge(local, &bus, bridgeRegistry())
+	if err := bridge.Send(bridgePing{N: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	ran := make(chan error, 1)
+	go func() { ran <- bridge.Run() }()
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(remote).ReadString('\n')
+		lines <- line
+	}()
+	if line := receive(t, lines); line != `{"type":"event.bridgePing","event":{"N":7}}`+"\n" {
+		t.Errorf("remote received %q, want the ping sent before Run", line)
+	}
+
+	if err := bridge.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := receive(t, ran); err != nil {
+		t.Errorf("Run returned %v after Close", err)
+	}
+}
@@ -0,0 +1,322 @@
+package event
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Bus is a type-safe event dispatcher that routes events to handlers by their concrete type.
+// New event types need no registration, simply Subscribe and Publish them. The zero value is ready to use.
+type Bus struct {
+	// RecoverPanics isolates handlers from each other. A panicking handler no longer takes down the dispatch,
+	// instead the panic is returned from Dispatch as a *ListenerPanicError and the remaining handlers still run.
+	RecoverPanics bool
+
+	mutex    sync.Mutex
+	handlers map[reflect.Type][]*handler
+	tracers  []*tracer
+	deferred *Queue
+}
+
+// tracer is a Tracer attached to the bus
+type tracer struct {
+	Tracer
+	removed atomic.Bool
+}
+
+// handler is a single callback registered on the bus
+type handler struct {
+	call     func(Event) error
+	listener string // Describes who subscribed, for error reporting
+	priority int
+	removed  atomic.Bool
+}
+
+// Subscribe registers a handler that is called every time an event of type E is published on the bus.
+// Cancel the returned subscription to remove it.
+func Subscribe[E any](bus *Bus, fn func(E)) *Subscription {
+	return SubscribePriority(bus, DefaultPriority, fn)
+}
+
+// SubscribePriority is Subscribe with an explicit priority. Higher priorities are called first,
+// handlers with equal priority are called in the order they subscribed.
+func SubscribePriority[E any](bus *Bus, priority int, fn func(E)) *Subscription {
+	return bus.add(typeOf[E](), priority, funcName(fn), func(e Event) error {
+		fn(e.(E))
+		return nil
+	})
+}
+
+// SubscribeErr is Subscribe for handlers that can fail. Their errors are collected and returned from the dispatch.
+func SubscribeErr[E any](bus *Bus, fn func(E) error) *Subscription {
+	return SubscribeErrPriority(bus, DefaultPriority, fn)
+}
+
+// SubscribeErrPriority is SubscribeErr with an explicit priority, see SubscribePriority
+func SubscribeErrPriority[E any](bus *Bus, priority int, fn func(E) error) *Subscription {
+	return bus.add(typeOf[E](), priority, funcName(fn), func(e Event) error { return fn(e.(E)) })
+}
+
+// Observe registers a handler that is called for every event delivered on the bus, whatever its type.
+// Observers run before the event's own handlers and can't be stopped by them, which makes them suited for tooling.
+func Observe(bus *Bus, fn func(Event)) *Subscription {
+	return bus.add(anyEvent, DefaultPriority, funcName(fn), func(e Event) error {
+		fn(e)
+		return nil
+	})
+}
+
+// Publish sends an event to every handler subscribed to its type on the bus.
+// Returns whether a handler stopped or cancelled the event, and the errors of any failed handlers as Errors.
+// Like Dispatch, a nil interface value is considered unknown.
+func Publish[E any](bus *Bus, e E) (bool, error) {
+	t := typeOf[E]()
+	if t.Kind() == reflect.Interface {
+		if t = reflect.TypeOf(e); t == nil {
+			return false, &UnknownEventError{}
+		}
+	}
+	return bus.publish(t, e)
+}
+
+// Dispatch sends an event to every handler subscribed to its dynamic type, reporting whether a handler consumed it.
+// The bus accepts any type, so events without handlers are dropped and only a nil event is considered unknown.
+// Dispatchers built on a bus should reject the events they do not support themselves, see Dispatcher.
+func (bus *Bus) Dispatch(e Event) (bool, error) {
+	if e == nil {
+		return false, &UnknownEventError{}
+	}
+	return bus.publish(reflect.TypeOf(e), e)
+}
+
+// Defer switches the bus to queued mode, where published events wait in the queue until it is flushed.
+// Events implementing Propagator are still processed immediately, since whoever raised them needs the outcome.
+// Passing nil returns the bus to processing events immediately.
+func (bus *Bus) Defer(queue *Queue) {
+	bus.mutex.Lock()
+	bus.deferred = queue
+	bus.mutex.Unlock()
+}
+
+// Trace attaches a Tracer that observes every dispatch on the bus, with timings for each listener.
+// Cancel the returned subscription to detach it.
+func (bus *Bus) Trace(t Tracer) *Subscription {
+	entry := &tracer{Tracer: t}
+	bus.mutex.Lock()
+	bus.tracers = append(bus.tracers[:len(bus.tracers):len(bus.tracers)], entry)
+	bus.mutex.Unlock()
+	return newSubscription(func() {
+		entry.removed.Store(true)
+		bus.mutex.Lock()
+		defer bus.mutex.Unlock()
+		remaining := make([]*tracer, 0, len(bus.tracers))
+		for _, other := range bus.tracers {
+			if other != entry {
+				remaining = append(remaining, other)
+			}
+		}
+		bus.tracers = remaining
+	})
+}
+
+func (bus *Bus) add(t reflect.Type, priority int, listener string, call func(Event) error) *Subscription {
+	return bus.addHandler(t, &handler{call: call, listener: listener, priority: priority})
+}
+
+func (bus *Bus) addHandler(t reflect.Type, h *handler) *Subscription {
+	priority := h.priority
+	bus.mutex.Lock()
+	if bus.handlers == nil {
+		bus.handlers = make(map[reflect.Type][]*handler)
+	}
+	// Handler slices are copy on write so a dispatch in progress keeps iterating its own snapshot
+	handlers := bus.handlers[t]
+	index := len(handlers)
+	for index > 0 && handlers[index-1].priority < priority {
+		index--
+	}
+	inserted := make([]*handler, 0, len(handlers)+1)
+	inserted = append(inserted, handlers[:index]...)
+	inserted = append(inserted, h)
+	bus.handlers[t] = append(inserted, handlers[index:]...)
+	bus.mutex.Unlock()
+	return newSubscription(func() { bus.remove(t, h) })
+}
+
+func (bus *Bus) remove(t reflect.Type, h *handler) {
+	h.removed.Store(true)
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	handlers := bus.handlers[t]
+	remaining := make([]*handler, 0, len(handlers))
+	for _, other := range handlers {
+		if other != h {
+			remaining = append(remaining, other)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(bus.handlers, t)
+	} else {
+		bus.handlers[t] = remaining
+	}
+}
+
+func (bus *Bus) publish(t reflect.Type, e Event) (bool, error) {
+	bus.mutex.Lock()
+	deferred := bus.deferred
+	bus.mutex.Unlock()
+	if _, ok := e.(Propagator); deferred != nil && !ok {
+		deferred.post(func() error {
+			_, err := bus.deliver(t, e, nil)
+			return err
+		})
+		return false, nil
+	}
+	return bus.deliver(t, e, nil)
+}
+
+// deliver calls the observers and handlers of an event right away. The skip handler, if any, is not called.
+func (bus *Bus) deliver(t reflect.Type, e Event, skip *handler) (bool, error) {
+	bus.mutex.Lock()
+	observers := bus.handlers[anyEvent]
+	handlers := bus.handlers[t]
+	tracers := bus.tracers
+	recoverPanics := bus.RecoverPanics
+	bus.mutex.Unlock()
+
+	var trace *DispatchTrace
+	if len(tracers) > 0 {
+		trace = &DispatchTrace{Type: t.String(), Event: e, Start: time.Now()}
+	}
+	var errs Errors
+	call := func(h *handler) {
+		var started time.Time
+		if trace != nil {
+			started = time.Now()
+		}
+		err := h.invoke(e, recoverPanics)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if trace != nil {
+			trace.Listeners = append(trace.Listeners, ListenerTrace{Listener: h.listener, Duration: time.Since(started), Err: err})
+		}
+	}
+
+	for _, h := range observers {
+		if h != skip && !h.removed.Load() {
+			call(h)
+		}
+	}
+	for _, h := range handlers {
+		if consumed(e) {
+			break
+		}
+		// A handler may have been cancelled by an earlier handler during this dispatch
+		if !h.removed.Load() {
+			call(h)
+		}
+	}
+
+	wasConsumed, err := consumed(e), errs.errorOrNil()
+	if trace != nil {
+		trace.Duration = time.Since(trace.Start)
+		trace.Consumed = wasConsumed
+		trace.Err = err
+		for _, t := range tracers {
+			if !t.removed.Load() {
+				t.TraceDispatch(*trace)
+			}
+		}
+	}
+	return wasConsumed, err
+}
+
+func (h *handler) invoke(e Event, recoverPanics bool) (err error) {
+	if recoverPanics {
+		defer func() {
+			if value := recover(); value != nil {
+				err = &ListenerPanicError{Listener: h.listener, Event: e, Value: value, Stack: debug.Stack()}
+			}
+		}()
+	}
+	if err := h.call(e); err != nil {
+		return &ListenerError{Listener: h.listener, Event: e, Err: err}
+	}
+	return nil
+}
+
+func typeOf[E any]() reflect.Type {
+	return reflect.TypeOf((*E)(nil)).Elem()
+}
+
+// observed is the type observers are registered under. Nothing outside the package can name it,
+// so unlike Event or any it can't be used to Subscribe, and no event is ever delivered as it.
+type observed struct{}
+
+// anyEvent keys the observers in the handler map
+var anyEvent = typeOf[observed]()
+
+func funcName(fn interface{}) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		return f.Name()
+	}
+	return fmt.Sprintf("%T", fn)
+}
+
+// Binding adapts one listener interface onto a Bus, returning nil if the subscriber does not implement it. See Listener.
+type Binding func(bus *Bus, subscriber Subscriber) *Subscription
+
+// Listener creates a Binding that subscribes call for events of type E whenever the subscriber implements L.
+// Method expressions make this concise, i.e. event.Listener(WindowResizedListener.OnWindowResized)
+// Subscribers implementing Prioritized are subscribed with their priority.
+func Listener[E any, L any](call func(L, E)) Binding {
+	return ListenerErr(func(listener L, e E) error {
+		call(listener, e)
+		return nil
+	})
+}
+
+// ListenerErr is Listener for listener interfaces whose methods return an error
+func ListenerErr[E any, L any](call func(L, E) error) Binding {
+	return func(bus *Bus, subscriber Subscriber) *Subscription {
+		listener, ok := subscriber.(L)
+		if !ok {
+			return nil
+		}
+		priority := DefaultPriority
+		if prioritized, ok := subscriber.(Prioritized); ok {
+			priority = prioritized.Priority()
+		}
+		return bus.add(typeOf[E](), priority, fmt.Sprintf("%T", subscriber), func(e Event) error { return call(listener, e.(E)) })
+	}
+}
+
+// Bindings is the set of listener interfaces a dispatcher supports
+type Bindings []Binding
+
+// Bind subscribes the subscriber for every listener interface it implements, returning a single subscription covering all of them.
+// Returns an UnknownSubscriberError if it implements none of them.
+func (bindings Bindings) Bind(bus *Bus, subscriber Subscriber) (*Subscription, error) {
+	var sub *Subscription
+	for _, binding := range bindings {
+		bound := binding(bus, subscriber)
+		if bound == nil {
+			continue
+		}
+		if sub == nil {
+			sub = bound
+		} else {
+			sub.join(bound)
+		}
+	}
+	if sub == nil {
+		return nil, &UnknownSubscriberError{}
+	}
+	return sub, nil
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+type testEvent struct{ N int }
+
+func TestPublishNilInterface(t *testing.T) {
+	var bus Bus
+	var e Event
+	_, err := Publish(&bus, e)
+	var unknown *UnknownEventError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Publish(nil) returned %v, want an UnknownEventError", err)
+	}
+}
+
+func TestPublishInterfaceUsesDynamicType(t *testing.T) {
+	var bus Bus
+	var got []int
+	Subscribe(&bus, func(e testEvent) { got = append(got, e.N) })
+
+	var e Event = testEvent{N: 1}
+	if _, err := Publish(&bus, e); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("handler received %v, want [1]", got)
+	}
+}
@@ -1,5 +1,10 @@
 package event
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Subscriber represents an object that can subscribe to a dispatcher.
 type Subscriber interface{}
 
@@ -7,11 +12,16 @@ type Subscriber interface{}
 type Event interface{}
 
 // Dispatcher is the interface for an event dispatcher
+// Most implementations should be built on a Bus, adapting their listener interfaces with Bindings.
 type Dispatcher interface {
-	// Should use type switch to check it implements and interface with a supported callback function, then remember it.
-	Subscribe(Subscriber) error
-	// Should use type switch to check event data type and call the correct subscribers
-	Dispatch(Event) error
+	// Should check which supported listener interfaces the subscriber implements, then remember it.
+	// The returned Subscription must stop all of the subscriber's callbacks when cancelled.
+	Subscribe(Subscriber) (*Subscription, error)
+	// Should call the subscribers of the event's type, in priority order, until one of them consumes it.
+	// Returns whether the event was consumed, see Propagation, and the errors of any failed subscribers as Errors,
+	// or an UnknownEventError if the dispatcher does not support it.
+	// Dispatchers that defer events, like Queue, can't know yet and report false.
+	Dispatch(Event) (bool, error)
 }
 
 // UnknownSubscriberError should be thrown if the interface of the subscriber is not supported by the dispatcher
@@ -25,6 +35,70 @@ func (err *UnknownSubscriberError) Error() string {
 // UnknownEventError should be thrown if the struct trying to be dispatched is not recognized as a valid event type
 type UnknownEventError struct{}
 
+// Error implements the error interface
 func (err *UnknownEventError) Error() string {
 	return "Event unsupported by dispatcher"
 }
+
+// ListenerError wraps an error returned by a listener while dispatching an event
+type ListenerError struct {
+	Listener string // The listener's type, or the handler function's name
+	Event    Event  // The event being dispatched
+	Err      error
+}
+
+// Error implements the error interface
+func (err *ListenerError) Error() string {
+	return fmt.Sprintf("%s failed handling %T: %s", err.Listener, err.Event, err.Err.Error())
+}
+
+// Unwrap returns the listener's error
+func (err *ListenerError) Unwrap() error {
+	return err.Err
+}
+
+// ListenerPanicError is a panic recovered from a listener while dispatching an event. See Bus.RecoverPanics.
+type ListenerPanicError struct {
+	Listener string      // The listener's type, or the handler function's name
+	Event    Event       // The event being dispatched
+	Value    interface{} // The value passed to panic
+	Stack    []byte      // Stack trace of the panicking goroutine
+}
+
+// Error implements the error interface
+func (err *ListenerPanicError) Error() string {
+	return fmt.Sprintf("%s panicked handling %T: %v\n%s", err.Listener, err.Event, err.Value, err.Stack)
+}
+
+// Errors aggregates the errors of every listener that failed during a dispatch
+type Errors []error
+
+// Error implements the error interface
+func (errs Errors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d listener(s) failed: %s", len(errs), strings.Join(messages, "; "))
+}
+
+// Unwrap allows errors.Is and errors.As to look through every aggregated error
+func (errs Errors) Unwrap() []error {
+	return errs
+}
+
+// add appends an error, flattening it if it is itself an Errors
+func (errs Errors) add(err error) Errors {
+	if nested, ok := err.(Errors); ok {
+		return append(errs, nested...)
+	}
+	return append(errs, err)
+}
+
+// errorOrNil avoids returning a non nil error interface holding an empty list
+func (errs Errors) errorOrNil() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,103 @@
+package event
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errTest = errors.New("test failure")
+
+type testErrListener struct{}
+
+func (listener *testErrListener) OnTest(e testEvent) error { return errTest }
+
+func TestDispatchAggregatesErrors(t *testing.T) {
+	var bus Bus
+	ran := 0
+	SubscribeErr(&bus, func(e testEvent) error { return errTest })
+	Subscribe(&bus, func(e testEvent) { ran++ })
+	SubscribeErr(&bus, func(e testEvent) error { return errors.New("other failure") })
+
+	_, err := bus.Dispatch(testEvent{N: 7})
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Dispatch returned %v, want Errors holding both failures", err)
+	}
+	if ran != 1 {
+		t.Error("a failing handler stopped the others from running")
+	}
+	if !errors.Is(err, errTest) {
+		t.Error("errors.Is does not find a listener's error through Errors")
+	}
+	var listenerErr *ListenerError
+	if !errors.As(errs[0], &listenerErr) || listenerErr.Event != (testEvent{N: 7}) || !strings.Contains(listenerErr.Listener, "TestDispatchAggregatesErrors") {
+		t.Errorf("first error is %#v, want a ListenerError naming the handler and carrying the event", errs[0])
+	}
+	if !strings.HasPrefix(err.Error(), "2 listener(s) failed: ") {
+		t.Errorf("error message is %q", err.Error())
+	}
+}
+
+func TestListenerErrNamesSubscriber(t *testing.T) {
+	var bus Bus
+	bindings := Bindings{ListenerErr(func(l interface{ OnTest(testEvent) error }, e testEvent) error { return l.OnTest(e) })}
+	if _, err := bindings.Bind(&bus, &testErrListener{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := bus.Dispatch(testEvent{})
+	var listenerErr *ListenerError
+	if !errors.As(err, &listenerErr) || listenerErr.Listener != "*event.testErrListener" || listenerErr.Unwrap() != errTest {
+		t.Errorf("Dispatch returned %v, want a ListenerError from *event.testErrListener", err)
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	bus := Bus{RecoverPanics: true}
+	ran := false
+	Subscribe(&bus, func(e testEvent) { panic("boom") })
+	Subscribe(&bus, func(e testEvent) { ran = true })
+
+	_, err := bus.Dispatch(testEvent{})
+	var panicErr *ListenerPanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Dispatch returned %v, want a ListenerPanicError", err)
+	}
+	if panicErr.Value != "boom" || len(panicErr.Stack) == 0 || !strings.Contains(panicErr.Listener, "TestRecoverPanics") {
+		t.Errorf("recovered %#v, want the panic value, its stack and the handler's name", panicErr)
+	}
+	if !ran {
+		t.Error("the handler after the panicking one did not run")
+	}
+}
+
+func TestPanicsPropagateByDefault(t *testing.T) {
+	var bus Bus
+	Subscribe(&bus, func(e testEvent) { panic("boom") })
+	defer func() {
+		if recover() != "boom" {
+			t.Error("the panic did not reach the dispatcher's caller")
+		}
+	}()
+	_, _ = bus.Dispatch(testEvent{})
+}
+
+func TestQueueFlushFlattensErrors(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	SubscribeErr(&dispatcher.bus, func(e testEvent) error { return errTest })
+	SubscribeErr(&dispatcher.bus, func(e testEvent) error { return errTest })
+	queue := NewQueue(dispatcher)
+	queue.Post(testEvent{})
+	queue.Post(testEvent{})
+
+	var errs Errors
+	if err := queue.Flush(); !errors.As(err, &errs) || len(errs) != 4 {
+		t.Fatalf("Flush returned %v, want the 4 listener errors in one flat list", err)
+	}
+	for _, err := range errs {
+		var listenerErr *ListenerError
+		if !errors.As(err, &listenerErr) {
+			t.Errorf("flattened error %v is not a ListenerError", err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package event
+
+import "sync/atomic"
+
+// Propagation tracks whether an event has been handled while it is being dispatched.
+// Embed a *Propagation in events listeners should be able to stop or cancel. Copies of the event share it,
+// so the code raising the event can check the outcome after dispatching. The zero value is ready to use,
+// but the event must be raised with one, i.e. WindowCloseRequestedEvent{..., &event.Propagation{}}.
+// Stopping or cancelling an event raised with a nil *Propagation panics, as the outcome would be lost.
+type Propagation struct {
+	stopped   atomic.Bool
+	cancelled atomic.Bool
+}
+
+// StopPropagation marks the event as handled. Listeners with a lower priority will not receive it.
+func (propagation *Propagation) StopPropagation() {
+	propagation.mustExist("StopPropagation")
+	propagation.stopped.Store(true)
+}
+
+// Cancel vetoes whatever action the event announces. A cancelled event also stops propagating.
+func (propagation *Propagation) Cancel() {
+	propagation.mustExist("Cancel")
+	propagation.cancelled.Store(true)
+	propagation.stopped.Store(true)
+}
+
+// Stopped returns whether a listener has stopped the event from propagating
+func (propagation *Propagation) Stopped() bool {
+	return propagation != nil && propagation.stopped.Load()
+}
+
+// Cancelled returns whether a listener has cancelled the event
+func (propagation *Propagation) Cancelled() bool {
+	return propagation != nil && propagation.cancelled.Load()
+}
+
+// mustExist panics if the event was raised without a Propagation to record the outcome in
+func (propagation *Propagation) mustExist(method string) {
+	if propagation == nil {
+		panic("event: " + method + " called on an event raised without a *Propagation, the outcome would be lost")
+	}
+}
+
+// self lets events embedding a *Propagation hand it over, i.e. so a Route can share it
+func (propagation *Propagation) self() *Propagation {
+	return propagation
+}
+
+// Propagator is implemented by events that embed a *Propagation
+type Propagator interface {
+	Stopped() bool
+	Cancelled() bool
+}
+
+// consumed returns whether a listener has stopped or cancelled the event
+func consumed(e Event) bool {
+	p, ok := e.(Propagator)
+	return ok && p.Stopped()
+}
+
+// Prioritized can be implemented by subscribers to control the order they are called in, relative to other subscribers.
+// Higher priorities are called first. Subscribers that don't implement it have DefaultPriority.
+type Prioritized interface {
+	Priority() int
+}
+
+// DefaultPriority is the priority of subscribers and handlers that don't specify one
+const DefaultPriority = 0
@@ -0,0 +1,120 @@
+package event
+
+import "testing"
+
+type testCancellable struct {
+	*Propagation
+}
+
+// testPrioritized is a testListener with a priority
+type testPrioritized struct {
+	testListener
+	priority int
+	calls    *[]int
+}
+
+func (listener *testPrioritized) OnTest(e testEvent) {
+	*listener.calls = append(*listener.calls, listener.priority)
+}
+func (listener *testPrioritized) Priority() int { return listener.priority }
+
+func TestPriorityOrder(t *testing.T) {
+	var bus Bus
+	var got []string
+	Subscribe(&bus, func(e testEvent) { got = append(got, "default") })
+	SubscribePriority(&bus, 10, func(e testEvent) { got = append(got, "high") })
+	SubscribePriority(&bus, -10, func(e testEvent) { got = append(got, "low") })
+	Subscribe(&bus, func(e testEvent) { got = append(got, "default, later") })
+
+	if _, err := bus.Dispatch(testEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"high", "default", "default, later", "low"}
+	if len(got) != len(want) {
+		t.Fatalf("called %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("called %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPrioritizedSubscriber(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	var calls []int
+	for _, priority := range []int{1, 3, 2} {
+		if _, err := dispatcher.Subscribe(&testPrioritized{priority: priority, calls: &calls}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, _ = dispatcher.Dispatch(testEvent{})
+	if len(calls) != 3 || calls[0] != 3 || calls[1] != 2 || calls[2] != 1 {
+		t.Errorf("subscribers were called in priority order %v, want [3 2 1]", calls)
+	}
+}
+
+func TestStopPropagation(t *testing.T) {
+	var bus Bus
+	var got []string
+	SubscribePriority(&bus, 1, func(e testCancellable) {
+		got = append(got, "first")
+		e.StopPropagation()
+	})
+	Subscribe(&bus, func(e testCancellable) { got = append(got, "second") })
+
+	e := testCancellable{&Propagation{}}
+	consumed, err := bus.Dispatch(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !consumed || !e.Stopped() || e.Cancelled() {
+		t.Errorf("consumed %v, stopped %v, cancelled %v, want a stopped event that is not cancelled", consumed, e.Stopped(), e.Cancelled())
+	}
+	if len(got) != 1 {
+		t.Errorf("called %v, the second handler should not see a stopped event", got)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	var bus Bus
+	calls := 0
+	Subscribe(&bus, func(e testCancellable) { e.Cancel() })
+	Subscribe(&bus, func(e testCancellable) { calls++ })
+
+	e := testCancellable{&Propagation{}}
+	if consumed, _ := Publish(&bus, e); !consumed {
+		t.Error("a cancelled event was not reported as consumed")
+	}
+	if !e.Cancelled() || !e.Stopped() {
+		t.Error("cancelling did not also stop the event")
+	}
+	if calls != 0 {
+		t.Error("a cancelled event kept propagating")
+	}
+}
+
+func TestCancelWithoutPropagationPanics(t *testing.T) {
+	var bus Bus
+	Subscribe(&bus, func(e testCancellable) { e.Cancel() })
+	defer func() {
+		if recover() == nil {
+			t.Error("cancelling an event raised without a Propagation did not panic")
+		}
+	}()
+	e := testCancellable{}
+	if e.Stopped() || e.Cancelled() {
+		t.Error("an event without a Propagation reads as handled")
+	}
+	_, _ = bus.Dispatch(e)
+}
+
+func TestDeferredBusDeliversPropagatorsImmediately(t *testing.T) {
+	var bus Bus
+	bus.Defer(NewQueue(&testDispatcher{}))
+	Subscribe(&bus, func(e testCancellable) { e.Cancel() })
+	e := testCancellable{&Propagation{}}
+	if consumed, _ := bus.Dispatch(e); !consumed || !e.Cancelled() {
+		t.Error("a deferred bus queued an event whose outcome the caller needs")
+	}
+}
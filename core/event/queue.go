@@ -0,0 +1,77 @@
+package event
+
+import "sync"
+
+// Queue collects events posted from any goroutine and dispatches them later, on whichever goroutine calls Flush.
+// This lets worker goroutines raise events without racing the listeners, which only ever run at a well defined point.
+// Queue implements Dispatcher, so it can stand in anywhere a deferred version of its target is wanted.
+type Queue struct {
+	target  Dispatcher
+	mutex   sync.Mutex
+	pending []func() error
+}
+
+// NewQueue creates a queue that delivers posted events to target
+func NewQueue(target Dispatcher) *Queue {
+	return &Queue{target: target}
+}
+
+// Post queues an event for the queue's target. Safe to call from any goroutine.
+func (queue *Queue) Post(e Event) {
+	queue.PostTo(queue.target, e)
+}
+
+// PostTo queues an event for a specific dispatcher. Safe to call from any goroutine.
+func (queue *Queue) PostTo(dispatcher Dispatcher, e Event) {
+	queue.post(func() error {
+		_, err := dispatcher.Dispatch(e)
+		return err
+	})
+}
+
+// Flush dispatches every event queued so far, in the order they were posted.
+// Events posted while flushing are left for the next flush. Every dispatch error is returned, flattened into Errors.
+func (queue *Queue) Flush() error {
+	queue.mutex.Lock()
+	pending := queue.pending
+	queue.pending = nil
+	queue.mutex.Unlock()
+
+	var errs Errors
+	for _, deliver := range pending {
+		if err := deliver(); err != nil {
+			errs = errs.add(err)
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// Len returns the number of events waiting to be flushed
+func (queue *Queue) Len() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return len(queue.pending)
+}
+
+// Subscribe implements the Dispatcher interface by subscribing to the queue's target
+func (queue *Queue) Subscribe(subscriber Subscriber) (*Subscription, error) {
+	return queue.target.Subscribe(subscriber)
+}
+
+// Dispatch implements the Dispatcher interface. The event is posted rather than processed immediately,
+// so no listener has seen it yet and it is always reported as not consumed. Events whose outcome matters,
+// i.e. ones embedding a *Propagation, should be dispatched to the target directly instead.
+
+func (queue *Queue) Dispatch(e Event) (bool, error) {
+	if e == nil {
+		return false, &UnknownEventError{}
+	}
+	queue.Post(e)
+	return false, nil
+}
+
+func (queue *Queue) post(deliver func() error) {
+	queue.mutex.Lock()
+	queue.pending = append(queue.pending, deliver)
+	queue.mutex.Unlock()
+}
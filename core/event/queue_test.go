@@ -0,0 +1,133 @@
+package event
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestQueueFlushOrder(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	var got []int
+	Subscribe(&dispatcher.bus, func(e testEvent) { got = append(got, e.N) })
+	queue := NewQueue(dispatcher)
+	for n := 0; n < 3; n++ {
+		queue.Post(testEvent{N: n})
+	}
+	if len(got) != 0 || queue.Len() != 3 {
+		t.Fatalf("posted events were delivered before flushing, got %v with %d queued", got, queue.Len())
+	}
+	if err := queue.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 || queue.Len() != 0 {
+		t.Errorf("flushed %v with %d left, want [0 1 2] in order", got, queue.Len())
+	}
+}
+
+func TestQueuePostFromHandler(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	queue := NewQueue(dispatcher)
+	var got []int
+	Subscribe(&dispatcher.bus, func(e testEvent) {
+		got = append(got, e.N)
+		if e.N < 2 {
+			queue.Post(testEvent{N: e.N + 1})
+		}
+	})
+	queue.Post(testEvent{N: 0})
+	for flush := 1; flush <= 3; flush++ {
+		if err := queue.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != flush {
+			t.Fatalf("flush %d delivered %v, events posted while flushing must wait for the next flush", flush, got)
+		}
+	}
+	if queue.Len() != 0 {
+		t.Errorf("%d events left after the chain ended", queue.Len())
+	}
+}
+
+func TestQueueConcurrentPost(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	queue := NewQueue(dispatcher)
+	const posters, posts = 8, 100
+	delivered := 0
+	Subscribe(&dispatcher.bus, func(e testEvent) { delivered++ })
+
+	var wg sync.WaitGroup
+	for p := 0; p < posters; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < posts; n++ {
+				queue.Post(testEvent{N: n})
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	// Handlers only ever run on this goroutine, however many goroutines post
+	for flushing := true; flushing; {
+		select {
+		case <-done:
+			flushing = false
+		default:
+		}
+		if err := queue.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if delivered != posters*posts {
+		t.Errorf("delivered %d events, want %d", delivered, posters*posts)
+	}
+}
+
+func TestQueuePostTo(t *testing.T) {
+	target, other := &testDispatcher{}, &testDispatcher{}
+	var toTarget, toOther int
+	Subscribe(&target.bus, func(e testEvent) { toTarget++ })
+	Subscribe(&other.bus, func(e testEvent) { toOther++ })
+	queue := NewQueue(target)
+	queue.PostTo(other, testEvent{})
+	if err := queue.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if toTarget != 0 || toOther != 1 {
+		t.Errorf("the target got %d events and the other dispatcher %d, want 0 and 1", toTarget, toOther)
+	}
+}
+
+func TestQueueFlushReturnsDispatchErrors(t *testing.T) {
+	queue := NewQueue(&testDispatcher{})
+	var unknown *UnknownEventError
+	if _, err := queue.Dispatch(nil); !errors.As(err, &unknown) {
+		t.Errorf("Dispatch(nil) returned %v, want an UnknownEventError", err)
+	}
+	queue.PostTo(&testDispatcher{}, nil)
+	if err := queue.Flush(); !errors.As(err, &unknown) {
+		t.Errorf("Flush returned %v, want the UnknownEventError of the nil event", err)
+	}
+}
+
+func TestBusDefer(t *testing.T) {
+	var bus Bus
+	queue := NewQueue(&testDispatcher{})
+	calls := 0
+	Subscribe(&bus, func(e testEvent) { calls++ })
+	bus.Defer(queue)
+	_, _ = bus.Dispatch(testEvent{})
+	if calls != 0 {
+		t.Fatal("a deferred bus delivered an event before flushing")
+	}
+	_ = queue.Flush()
+	bus.Defer(nil)
+	_, _ = bus.Dispatch(testEvent{})
+	if calls != 2 {
+		t.Errorf("handler was called %d times, want once on flush and once after Defer(nil)", calls)
+	}
+}
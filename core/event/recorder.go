@@ -0,0 +1,162 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Record is a single recorded event. A recording is a stream of records written as JSON, one per line.
+type Record struct {
+	Frame uint64          `json:"frame"` // Frame the event was dispatched on
+	Time  time.Duration   `json:"time"`  // Time since the recording started
+	Type  string          `json:"type"`  // Name the event's type is registered under
+	Event json.RawMessage `json:"event"` // The event itself
+}
+
+// Recorder captures events into a log that a Player can replay later, i.e. to reproduce a bug report.
+// Only event types in its Registry are recorded, anything else is skipped.
+type Recorder struct {
+	registry *Registry
+	mutex    sync.Mutex
+	encoder  *json.Encoder
+	started  time.Time
+	frame    uint64
+	err      error
+}
+
+// NewRecorder creates a recorder writing to w
+func NewRecorder(w io.Writer, registry *Registry) *Recorder {
+	return &Recorder{registry: registry, encoder: json.NewEncoder(w), started: time.Now()}
+}
+
+// SetFrame sets the frame number stamped on events recorded from now on
+func (recorder *Recorder) SetFrame(frame uint64) {
+	recorder.mutex.Lock()
+	recorder.frame = frame
+	recorder.mutex.Unlock()
+}
+
+// Record writes an event to the log. Returns an error if writing fails, unregistered events are silently skipped.
+func (recorder *Recorder) Record(e Event) error {
+	name, payload, err := recorder.registry.Encode(e)
+	var unregistered *UnregisteredEventError
+	if errors.As(err, &unregistered) {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if err == nil {
+		err = recorder.encoder.Encode(Record{
+			Frame: recorder.frame,
+			Time:  time.Since(recorder.started),
+			Type:  name,
+			Event: payload,
+		})
+	}
+	if err != nil && recorder.err == nil {
+		recorder.err = err
+	}
+	return err
+}
+
+// Err returns the first error the recorder ran into. Errors are sticky, since a recording with holes can't be trusted.
+func (recorder *Recorder) Err() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.err
+}
+
+// Attach records every event delivered on a bus until the returned subscription is cancelled
+func (recorder *Recorder) Attach(bus *Bus) *Subscription {
+	return Observe(bus, func(e Event) { _ = recorder.Record(e) })
+}
+
+// Wrap returns a Dispatcher that records every event dispatched through it before passing it on to dispatcher
+func (recorder *Recorder) Wrap(dispatcher Dispatcher) Dispatcher {
+	return &recordingDispatcher{dispatcher, recorder}
+}
+
+type recordingDispatcher struct {
+	Dispatcher
+	recorder *Recorder
+}
+
+// Dispatch implements the Dispatcher interface
+func (dispatcher *recordingDispatcher) Dispatch(e Event) (bool, error) {
+	_ = dispatcher.recorder.Record(e)
+	return dispatcher.Dispatcher.Dispatch(e)
+}
+
+// Player replays a recording made by a Recorder, frame by frame
+type Player struct {
+	// Prepare is called on every event before it is dispatched, i.e. to point it at objects in the new session.
+	// Returning nil skips the event.
+	Prepare func(Event) Event
+
+	records  []Record
+	registry *Registry
+	next     int
+}
+
+// NewPlayer reads a whole recording from r
+func NewPlayer(r io.Reader, registry *Registry) (*Player, error) {
+	player := &Player{registry: registry}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to read record on line %d.\n Unmarshal Error: %s", line, err.Error())
+		}
+		player.records = append(player.records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+// Step dispatches every event recorded up to and including frame that hasn't been played yet, in recorded order.
+// Call it once per frame with the current frame number to reproduce the recording frame accurately.
+func (player *Player) Step(frame uint64, dispatcher Dispatcher) error {
+	var errs Errors
+	for ; player.next < len(player.records) && player.records[player.next].Frame <= frame; player.next++ {
+		record := player.records[player.next]
+		e, err := player.registry.Decode(record.Type, record.Event)
+		if err != nil {
+			errs = errs.add(err)
+			continue
+		}
+		if player.Prepare != nil {
+			if e = player.Prepare(e); e == nil {
+				continue
+			}
+		}
+		if _, err := dispatcher.Dispatch(e); err != nil {
+			errs = errs.add(err)
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// Done returns whether every recorded event has been played
+func (player *Player) Done() bool {
+	return player.next >= len(player.records)
+}
+
+// LastFrame returns the frame of the last recorded event
+func (player *Player) LastFrame() uint64 {
+	if len(player.records) == 0 {
+		return 0
+	}
+	return player.records[len(player.records)-1].Frame
+}
@@ -0,0 +1,148 @@
+package event
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testRegistry knows testEvent, but not testOtherEvent
+func testRegistry() *Registry {
+	registry := &Registry{}
+	Register[testEvent](registry, "event.testEvent")
+	return registry
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	registry := testRegistry()
+	var log bytes.Buffer
+	recorder := NewRecorder(&log, registry)
+	var bus Bus
+	sub := recorder.Attach(&bus)
+
+	recorder.SetFrame(1)
+	_, _ = bus.Dispatch(testEvent{N: 1})
+	_, _ = bus.Dispatch(testOtherEvent{})
+	recorder.SetFrame(3)
+	_, _ = bus.Dispatch(testEvent{N: 2})
+	_, _ = bus.Dispatch(testEvent{N: 3})
+	sub.Cancel()
+	_, _ = bus.Dispatch(testEvent{N: 4})
+	if err := recorder.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := NewPlayer(&log, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if player.LastFrame() != 3 {
+		t.Errorf("LastFrame is %d, want 3", player.LastFrame())
+	}
+	target := &testDispatcher{}
+	var got []int
+	Subscribe(&target.bus, func(e testEvent) { got = append(got, e.N) })
+	steps := []struct {
+		frame uint64
+		want  []int
+	}{{1, []int{1}}, {2, []int{1}}, {3, []int{1, 2, 3}}, {4, []int{1, 2, 3}}}
+	for _, step := range steps {
+		if err := player.Step(step.frame, target); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, step.want) {
+			t.Fatalf("after frame %d replayed %v, want %v", step.frame, got, step.want)
+		}
+	}
+	if !player.Done() {
+		t.Error("the player is not done after the last recorded frame")
+	}
+}
+
+func TestPlayerPrepare(t *testing.T) {
+	registry := testRegistry()
+	var log bytes.Buffer
+	recorder := NewRecorder(&log, registry)
+	for n := 1; n <= 3; n++ {
+		if err := recorder.Record(testEvent{N: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	player, err := NewPlayer(&log, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player.Prepare = func(e Event) Event {
+		if e.(testEvent).N == 2 {
+			return nil
+		}
+		return testEvent{N: e.(testEvent).N * 10}
+	}
+	target := &testDispatcher{}
+	var got []int
+	Subscribe(&target.bus, func(e testEvent) { got = append(got, e.N) })
+	if err := player.Step(0, target); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []int{10, 30}) {
+		t.Errorf("replayed %v, want [10 30] with the second event skipped", got)
+	}
+}
+
+func TestRecorderWrap(t *testing.T) {
+	var log bytes.Buffer
+	recorder := NewRecorder(&log, testRegistry())
+	target := &testDispatcher{}
+	calls := 0
+	Subscribe(&target.bus, func(e testEvent) { calls++ })
+	if _, err := recorder.Wrap(target).Dispatch(testEvent{N: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || !strings.Contains(log.String(), `"type":"event.testEvent","event":{"N":5}`) {
+		t.Errorf("wrapped dispatch called %d handlers and recorded %q", calls, log.String())
+	}
+}
+
+func TestPlayerErrors(t *testing.T) {
+	registry := testRegistry()
+	if _, err := NewPlayer(strings.NewReader("{\"frame\":1,\"type\":\"event.testEvent\",\"event\":{}}\nnot json\n"), registry); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("reading a malformed recording returned %v, want an error on line 2", err)
+	}
+
+	player, err := NewPlayer(strings.NewReader(`{"frame":1,"type":"event.gone","event":{}}`), registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var unregistered *UnregisteredEventError
+	if err := player.Step(1, &testDispatcher{}); !errors.As(err, &unregistered) || unregistered.Type != "event.gone" {
+		t.Errorf("replaying an unknown type returned %v, want an UnregisteredEventError", err)
+	}
+}
+
+func TestRegistryReplaces(t *testing.T) {
+	registry := testRegistry()
+	Register[testEvent](registry, "event.renamed")
+	if name, ok := registry.Name(testEvent{}); !ok || name != "event.renamed" {
+		t.Errorf("testEvent is registered as %q, want the newer name", name)
+	}
+	if _, err := registry.Decode("event.testEvent", []byte("{}")); err == nil {
+		t.Error("the old name still decodes")
+	}
+	e, err := registry.Decode("event.renamed", []byte(`{"N":4}`))
+	if err != nil || e != (testEvent{N: 4}) {
+		t.Errorf("Decode returned %v, %v", e, err)
+	}
+}
+
+func TestObserversAreNotEventHandlers(t *testing.T) {
+	var bus Bus
+	observed, subscribed := 0, 0
+	Observe(&bus, func(e Event) { observed++ })
+	Subscribe(&bus, func(e Event) { subscribed++ })
+	_, _ = bus.Dispatch(testEvent{})
+	if observed != 1 || subscribed != 0 {
+		t.Errorf("observer called %d times and Subscribe[Event] handler %d times, want 1 and 0", observed, subscribed)
+	}
+}
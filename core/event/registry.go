@@ -0,0 +1,82 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Registry is a table of event types known by a stable name, so events can be written out and read back in.
+// Used by the Recorder, Player and Bridge. The zero value is ready to use.
+type Registry struct {
+	mutex  sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+// Register adds the event type E to the registry under name. The name is what ends up in logs and on the wire,
+// so it should not change when code is refactored. Registering the same type or name twice replaces the old entry.
+func Register[E any](registry *Registry, name string) {
+	t := typeOf[E]()
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.byName == nil {
+		registry.byName = make(map[string]reflect.Type)
+		registry.byType = make(map[reflect.Type]string)
+	}
+	if old, ok := registry.byName[name]; ok {
+		delete(registry.byType, old)
+	}
+	if old, ok := registry.byType[t]; ok {
+		delete(registry.byName, old)
+	}
+	registry.byName[name] = t
+	registry.byType[t] = name
+}
+
+// Name returns the name an event's type was registered under
+func (registry *Registry) Name(e Event) (string, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	name, ok := registry.byType[reflect.TypeOf(e)]
+	return name, ok
+}
+
+// Encode serializes a registered event as JSON, returning the name of its type alongside it
+func (registry *Registry) Encode(e Event) (string, json.RawMessage, error) {
+	name, ok := registry.Name(e)
+	if !ok {
+		return "", nil, &UnregisteredEventError{Type: fmt.Sprintf("%T", e)}
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode event \"%s\".\n Marshal Error: %s", name, err.Error())
+	}
+	return name, payload, nil
+}
+
+// Decode deserializes an event of the type registered under name
+func (registry *Registry) Decode(name string, payload json.RawMessage) (Event, error) {
+	registry.mutex.RLock()
+	t, ok := registry.byName[name]
+	registry.mutex.RUnlock()
+	if !ok {
+		return nil, &UnregisteredEventError{Type: name}
+	}
+	ptr := reflect.New(t)
+	if err := json.Unmarshal(payload, ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode event \"%s\".\n Unmarshal Error: %s", name, err.Error())
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// UnregisteredEventError is returned when trying to serialize an event whose type is not in the Registry
+type UnregisteredEventError struct {
+	Type string
+}
+
+// Error implements the error interface
+func (err *UnregisteredEventError) Error() string {
+	return fmt.Sprintf("event type %s is not registered", err.Type)
+}
@@ -0,0 +1,234 @@
+package event
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Phase is the stage of a routed event's journey through a tree of nodes
+type Phase int
+
+// Declaring Phase enum values
+const (
+	CapturePhase Phase = iota // Travelling down from the root towards the target
+	TargetPhase               // Arrived at the target
+	BubblePhase               // Travelling back up from the target to the root
+)
+
+// Node is an element of a tree that routed events travel through, such as a UI widget or a scene node.
+// Implement it by embedding BaseNode.
+type Node interface {
+	Parent() Node     // The node above this one, nil for the root
+	Children() []Node // The nodes below this one
+
+	base() *BaseNode
+}
+
+// BaseNode implements the Node interface and holds the node's routed event handlers. The zero value is ready to use.
+type BaseNode struct {
+	mutex    sync.Mutex
+	parent   Node
+	children []Node
+	handlers [3]Bus // One per phase
+}
+
+// Parent implements the Node interface
+func (node *BaseNode) Parent() Node {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	return node.parent
+}
+
+// Children implements the Node interface
+func (node *BaseNode) Children() []Node {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	return append([]Node(nil), node.children...)
+}
+
+func (node *BaseNode) base() *BaseNode {
+	return node
+}
+
+// NodeCycleError is the error returned when attaching a node under itself or one of its descendants,
+// which would route events around the loop forever
+type NodeCycleError struct {
+	Parent Node
+	Child  Node
+}
+
+// Error implements the error interface
+func (err *NodeCycleError) Error() string {
+	return fmt.Sprintf("can not add %T as a child of %T, it is the node itself or one of its ancestors", err.Child, err.Parent)
+}
+
+// treeMutex serializes every change to the shape of a tree, so no other change can slip in between AddChild's cycle
+// check and the attach. Node mutexes still guard the fields, so reading the tree doesn't wait on it
+var treeMutex sync.Mutex
+
+// AddChild attaches child under parent, detaching it from its previous parent first.
+// Returns a NodeCycleError, leaving the tree unchanged, if child is parent or one of its ancestors
+func AddChild(parent Node, child Node) error {
+	treeMutex.Lock()
+	defer treeMutex.Unlock()
+	for node := parent; node != nil; node = node.Parent() {
+		if node.base() == child.base() {
+			return &NodeCycleError{Parent: parent, Child: child}
+		}
+	}
+	detach(child)
+	child.base().mutex.Lock()
+	child.base().parent = parent
+	child.base().mutex.Unlock()
+	parent.base().mutex.Lock()
+	parent.base().children = append(parent.base().children, child)
+	parent.base().mutex.Unlock()
+	return nil
+}
+
+// Detach removes a node from its parent, making it the root of its own tree
+func Detach(child Node) {
+	treeMutex.Lock()
+	defer treeMutex.Unlock()
+	detach(child)
+}
+
+// detach is Detach for callers already holding treeMutex
+func detach(child Node) {
+	child.base().mutex.Lock()
+	parent := child.base().parent
+	child.base().parent = nil
+	child.base().mutex.Unlock()
+	if parent == nil {
+		return
+	}
+	parent.base().mutex.Lock()
+	defer parent.base().mutex.Unlock()
+	children := parent.base().children
+	for i, other := range children {
+		if other == child {
+			parent.base().children = append(children[:i:i], children[i+1:]...)
+			break
+		}
+	}
+}
+
+// Route describes a routed event's progress through the tree. Stop or cancel it to end the journey.
+// If the event itself embeds a *Propagation, the route shares it.
+type Route struct {
+	*Propagation
+	Event   Event // The event being routed
+	Target  Node  // The node the event is aimed at
+	Current Node  // The node currently handling the event
+	Phase   Phase // The phase the route is currently in
+}
+
+// Listen registers a handler for events of type E routed through a node.
+// Capture handlers run on the way down and at the target, bubble handlers at the target and on the way back up,
+// and target handlers only when the node is the target itself.
+func Listen[E any](node Node, phase Phase, fn func(E, *Route)) *Subscription {
+	return ListenPriority(node, phase, DefaultPriority, fn)
+}
+
+// ListenPriority is Listen with an explicit priority, see SubscribePriority
+func ListenPriority[E any](node Node, phase Phase, priority int, fn func(E, *Route)) *Subscription {
+	return node.base().handlers[phase].add(typeOf[E](), priority, funcName(fn), func(e Event) error {
+		route := e.(*Route)
+		fn(route.Event.(E), route)
+		return nil
+	})
+}
+
+// Targeted is implemented by events that know which node they are aimed at, so a Router can route them
+type Targeted interface {
+	RouteTarget() Node
+}
+
+// Router sends events through a tree of nodes in capture, target and bubble phases.
+// It implements Dispatcher: Targeted events are routed to their target, anything else is routed to the root.
+type Router struct {
+	Root Node
+}
+
+// NewRouter creates a router for the tree under root
+func NewRouter(root Node) *Router {
+	return &Router{Root: root}
+}
+
+// Route sends an event to target, through its ancestors, and back up again.
+// Returns whether a handler stopped or cancelled the route, and the errors of any failed handlers as Errors.
+// Nodes that implement Dispatcher, such as a RootNode, also get the event dispatched to them on the way up.
+func (router *Router) Route(target Node, e Event) (bool, error) {
+	if e == nil {
+		return false, &UnknownEventError{}
+	}
+	route := &Route{Event: e, Target: target}
+	if propagating, ok := e.(interface{ self() *Propagation }); ok && propagating.self() != nil {
+		route.Propagation = propagating.self()
+	} else {
+		route.Propagation = &Propagation{}
+	}
+
+	// Snapshot the path first, so handlers changing the tree don't change this journey
+	var path []Node
+	for node := target; node != nil; node = node.Parent() {
+		path = append(path, node)
+	}
+
+	var errs Errors
+	visit := func(node Node, phase Phase, handlers Phase) {
+		if route.Stopped() {
+			return
+		}
+		route.Current = node
+		route.Phase = phase
+		if _, err := node.base().handlers[handlers].deliver(reflect.TypeOf(e), route, nil); err != nil {
+			errs = errs.add(err)
+		}
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		visit(path[i], CapturePhase, CapturePhase)
+	}
+	visit(target, TargetPhase, CapturePhase)
+	visit(target, TargetPhase, TargetPhase)
+	visit(target, TargetPhase, BubblePhase)
+	for i, node := range path {
+		if i > 0 {
+			visit(node, BubblePhase, BubblePhase)
+		}
+		if dispatcher, ok := node.(Dispatcher); ok && !route.Stopped() {
+			if _, err := dispatcher.Dispatch(e); err != nil {
+				errs = errs.add(err)
+			}
+		}
+	}
+	return route.Stopped(), errs.errorOrNil()
+}
+
+// Subscribe implements the Dispatcher interface by subscribing to the root, which must itself be a Dispatcher
+func (router *Router) Subscribe(subscriber Subscriber) (*Subscription, error) {
+	if dispatcher, ok := router.Root.(Dispatcher); ok {
+		return dispatcher.Subscribe(subscriber)
+	}
+	return nil, &UnknownSubscriberError{}
+}
+
+// Dispatch implements the Dispatcher interface
+func (router *Router) Dispatch(e Event) (bool, error) {
+	if targeted, ok := e.(Targeted); ok && targeted.RouteTarget() != nil {
+		return router.Route(targeted.RouteTarget(), e)
+	}
+	return router.Route(router.Root, e)
+}
+
+// RootNode makes any Dispatcher, such as a window, the root of a tree. Events bubbling up to it are dispatched to it.
+type RootNode struct {
+	BaseNode
+	Dispatcher
+}
+
+// NewRootNode creates a root node for dispatcher
+func NewRootNode(dispatcher Dispatcher) *RootNode {
+	return &RootNode{Dispatcher: dispatcher}
+}
@@ -0,0 +1,88 @@
+package event
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type testNode struct {
+	BaseNode
+	name string
+}
+
+type testClick struct {
+	target Node
+}
+
+func (e testClick) RouteTarget() Node {
+	return e.target
+}
+
+func TestRouterPhases(t *testing.T) {
+	root, panel, button := &testNode{name: "root"}, &testNode{name: "panel"}, &testNode{name: "button"}
+	if err := AddChild(root, panel); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddChild(panel, button); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, node := range []*testNode{root, panel, button} {
+		name := node.name
+		Listen(node, CapturePhase, func(e testClick, route *Route) { got = append(got, "capture "+name) })
+		Listen(node, BubblePhase, func(e testClick, route *Route) { got = append(got, "bubble "+name) })
+	}
+	Listen(button, TargetPhase, func(e testClick, route *Route) { got = append(got, "target button") })
+
+	if _, err := (&Router{Root: root}).Dispatch(testClick{target: button}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"capture root", "capture panel", "capture button", "target button", "bubble button", "bubble panel", "bubble root"}
+	if !slices.Equal(got, want) {
+		t.Errorf("routed through %v, want %v", got, want)
+	}
+}
+
+func TestAddChildRejectsCycles(t *testing.T) {
+	root, child, grandchild := &testNode{name: "root"}, &testNode{name: "child"}, &testNode{name: "grandchild"}
+	if err := AddChild(root, child); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddChild(child, grandchild); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name          string
+		parent, child Node
+	}{
+		{"itself", child, child},
+		{"parent", child, root},
+		{"grandparent", grandchild, root},
+	} {
+		var cycle *NodeCycleError
+		if err := AddChild(test.parent, test.child); !errors.As(err, &cycle) {
+			t.Errorf("adding a node under %s returned %v, want a NodeCycleError", test.name, err)
+		}
+	}
+	if root.Parent() != nil || child.Parent() != Node(root) || grandchild.Parent() != Node(child) {
+		t.Error("a rejected AddChild changed the tree")
+	}
+}
+
+func TestAddChildConcurrentCycle(t *testing.T) {
+	// Attaching two nodes under each other at the same time must never leave both attached
+	for i := 0; i < 1000; i++ {
+		a, b := &testNode{name: "a"}, &testNode{name: "b"}
+		var wait sync.WaitGroup
+		wait.Add(2)
+		go func() { defer wait.Done(); _ = AddChild(a, b) }()
+		go func() { defer wait.Done(); _ = AddChild(b, a) }()
+		wait.Wait()
+		if a.Parent() != nil && b.Parent() != nil {
+			t.Fatal("concurrent AddChild calls made a cycle")
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package event
+
+import (
+	"context"
+	"sync"
+)
+
+// Subscription is the handle returned when subscribing to a dispatcher. Cancel it to stop receiving events.
+// It is safe to cancel a subscription from any goroutine, including from within a callback being dispatched.
+type Subscription struct {
+	mutex     sync.Mutex
+	removers  []func()
+	cancelled bool
+	done      chan struct{}
+}
+
+func newSubscription(removers ...func()) *Subscription {
+	return &Subscription{removers: removers, done: make(chan struct{})}
+}
+
+// Cancel unsubscribes from every event this subscription covers. Cancelling more than once does nothing.
+func (sub *Subscription) Cancel() {
+	sub.mutex.Lock()
+	if sub.cancelled {
+		sub.mutex.Unlock()
+		return
+	}
+	sub.cancelled = true
+	removers := sub.removers
+	sub.removers = nil
+	close(sub.done)
+	sub.mutex.Unlock()
+
+	for _, remove := range removers {
+		remove()
+	}
+}
+
+// Cancelled returns whether the subscription has been cancelled
+func (sub *Subscription) Cancelled() bool {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	return sub.cancelled
+}
+
+// Done returns a channel that is closed once the subscription is cancelled
+func (sub *Subscription) Done() <-chan struct{} {
+	return sub.done
+}
+
+// Until ties the subscription to a context, cancelling it automatically when the context is done
+func (sub *Subscription) Until(ctx context.Context) *Subscription {
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Cancel()
+		case <-sub.done:
+		}
+	}()
+	return sub
+}
+
+// join merges the removers of other into this subscription, so cancelling one handle covers both
+func (sub *Subscription) join(other *Subscription) {
+	other.mutex.Lock()
+	removers := other.removers
+	other.removers = nil
+	other.mutex.Unlock()
+
+	sub.mutex.Lock()
+	sub.removers = append(sub.removers, removers...)
+	sub.mutex.Unlock()
+}
+
+// Scope ties the lifetime of many subscriptions to an owner, such as a scene or UI panel.
+// Cancelling the scope cancels everything added to it. The zero value is ready to use.
+type Scope struct {
+	mutex     sync.Mutex
+	subs      []*Subscription
+	cancelled bool
+	done      chan struct{}
+}
+
+// Add hands subscriptions over to the scope. If the scope is already cancelled they are cancelled immediately.
+func (scope *Scope) Add(subs ...*Subscription) {
+	scope.mutex.Lock()
+	if scope.cancelled {
+		scope.mutex.Unlock()
+		for _, sub := range subs {
+			if sub != nil {
+				sub.Cancel()
+			}
+		}
+		return
+	}
+	for _, sub := range subs {
+		if sub != nil {
+			scope.subs = append(scope.subs, sub)
+		}
+	}
+	scope.mutex.Unlock()
+}
+
+// Subscribe subscribes to a dispatcher for the lifetime of the scope
+func (scope *Scope) Subscribe(dispatcher Dispatcher, subscriber Subscriber) error {
+	sub, err := dispatcher.Subscribe(subscriber)
+	if err != nil {
+		return err
+	}
+	scope.Add(sub)
+	return nil
+}
+
+// Cancel cancels every subscription in the scope. Anything added afterwards is cancelled immediately.
+func (scope *Scope) Cancel() {
+	scope.mutex.Lock()
+	if scope.cancelled {
+		scope.mutex.Unlock()
+		return
+	}
+	subs := scope.subs
+	scope.subs = nil
+	scope.cancelled = true
+	if scope.done != nil {
+		close(scope.done)
+	}
+	scope.mutex.Unlock()
+
+	for _, sub := range subs {
+		sub.Cancel()
+	}
+}
+
+// Until cancels the scope automatically when the context is done
+func (scope *Scope) Until(ctx context.Context) {
+	scope.mutex.Lock()
+	if scope.cancelled {
+		scope.mutex.Unlock()
+		return
+	}
+	if scope.done == nil {
+		scope.done = make(chan struct{})
+	}
+	done := scope.done
+	scope.mutex.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			scope.Cancel()
+		case <-done:
+		}
+	}()
+}
@@ -0,0 +1,157 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type testOtherEvent struct{}
+
+type testNListener interface{ OnTest(e testEvent) }
+type testOtherListener interface{ OnOther(e testOtherEvent) }
+
+// testDispatcher is a Dispatcher with two listener interfaces
+type testDispatcher struct {
+	bus Bus
+}
+
+var testBindings = Bindings{
+	Listener(testNListener.OnTest),
+	Listener(testOtherListener.OnOther),
+}
+
+func (dispatcher *testDispatcher) Subscribe(subscriber Subscriber) (*Subscription, error) {
+	return testBindings.Bind(&dispatcher.bus, subscriber)
+}
+
+func (dispatcher *testDispatcher) Dispatch(e Event) (bool, error) {
+	return dispatcher.bus.Dispatch(e)
+}
+
+// testListener counts the events of both listener interfaces of testDispatcher
+type testListener struct {
+	tests, others int
+}
+
+func (listener *testListener) OnTest(e testEvent)       { listener.tests++ }
+func (listener *testListener) OnOther(e testOtherEvent) { listener.others++ }
+
+func TestSubscriptionCancelCoversEveryBinding(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	listener := &testListener{}
+	sub, err := dispatcher.Subscribe(listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = dispatcher.Dispatch(testEvent{})
+	_, _ = dispatcher.Dispatch(testOtherEvent{})
+	sub.Cancel()
+	_, _ = dispatcher.Dispatch(testEvent{})
+	_, _ = dispatcher.Dispatch(testOtherEvent{})
+	if listener.tests != 1 || listener.others != 1 {
+		t.Errorf("listener got %d and %d events, want 1 of each before cancelling", listener.tests, listener.others)
+	}
+}
+
+func TestSubscriptionCancelDuringDispatch(t *testing.T) {
+	var bus Bus
+	var calls []string
+	var self, later *Subscription
+	Subscribe(&bus, func(e testEvent) {
+		calls = append(calls, "first")
+		later.Cancel()
+	})
+	self = Subscribe(&bus, func(e testEvent) {
+		calls = append(calls, "self")
+		self.Cancel()
+	})
+	later = Subscribe(&bus, func(e testEvent) { calls = append(calls, "later") })
+
+	_, _ = bus.Dispatch(testEvent{})
+	_, _ = bus.Dispatch(testEvent{})
+	want := []string{"first", "self", "first"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls were %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls were %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestSubscriptionCancelTwice(t *testing.T) {
+	var bus Bus
+	calls := 0
+	sub := Subscribe(&bus, func(e testEvent) { calls++ })
+	sub.Cancel()
+	sub.Cancel()
+	if !sub.Cancelled() {
+		t.Error("Cancelled is false after cancelling")
+	}
+	select {
+	case <-sub.Done():
+	default:
+		t.Error("Done is not closed after cancelling")
+	}
+	_, _ = bus.Dispatch(testEvent{})
+	if calls != 0 {
+		t.Errorf("cancelled handler was called %d times", calls)
+	}
+}
+
+func TestSubscriptionUntil(t *testing.T) {
+	var bus Bus
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := Subscribe(&bus, func(e testEvent) {}).Until(ctx)
+	cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the subscription outlived its context")
+	}
+}
+
+func TestScopeCancel(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	listener := &testListener{}
+	var scope Scope
+	if err := scope.Subscribe(dispatcher, listener); err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	scope.Add(Subscribe(&dispatcher.bus, func(e testEvent) { calls++ }), nil)
+
+	scope.Cancel()
+	scope.Cancel()
+	_, _ = dispatcher.Dispatch(testEvent{})
+	_, _ = dispatcher.Dispatch(testOtherEvent{})
+	if listener.tests+listener.others+calls != 0 {
+		t.Error("subscriptions of a cancelled scope were called")
+	}
+
+	late := Subscribe(&dispatcher.bus, func(e testEvent) { calls++ })
+	scope.Add(late)
+	if !late.Cancelled() {
+		t.Error("a subscription added to a cancelled scope was not cancelled")
+	}
+	if err := scope.Subscribe(dispatcher, struct{}{}); err == nil {
+		t.Error("subscribing a subscriber without listener interfaces succeeded")
+	}
+}
+
+func TestScopeUntil(t *testing.T) {
+	var bus Bus
+	var scope Scope
+	sub := Subscribe(&bus, func(e testEvent) {})
+	scope.Add(sub)
+	ctx, cancel := context.WithCancel(context.Background())
+	scope.Until(ctx)
+	cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the scope outlived its context")
+	}
+}
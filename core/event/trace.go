@@ -0,0 +1,221 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// DispatchTrace describes a single dispatch, as seen by a Tracer
+type DispatchTrace struct {
+	Type      string          // The event's type
+	Event     Event           // The event itself
+	Start     time.Time       // When the dispatch started
+	Duration  time.Duration   // Time spent in the whole dispatch
+	Listeners []ListenerTrace // Every listener that was called, in order. Empty when traced with Traced
+	Consumed  bool            // Whether a listener consumed the event
+	Err       error           // What the dispatch returned
+}
+
+// ListenerTrace describes a single listener call within a dispatch
+type ListenerTrace struct {
+	Listener string        // The listener's type, or the handler function's name
+	Duration time.Duration // Time spent in the listener
+	Err      error         // Error or recovered panic, if any
+}
+
+// Tracer is middleware that observes dispatches. Attach one to a Bus with Bus.Trace, or wrap any Dispatcher with Traced.
+// TraceDispatch is called synchronously after every dispatch, so it should be cheap.
+type Tracer interface {
+	TraceDispatch(trace DispatchTrace)
+}
+
+// TracerFunc adapts a function to the Tracer interface
+type TracerFunc func(trace DispatchTrace)
+
+// TraceDispatch implements the Tracer interface
+func (fn TracerFunc) TraceDispatch(trace DispatchTrace) {
+	fn(trace)
+}
+
+// Traced wraps any Dispatcher so every Dispatch call through it is traced. Only the total time is known this way,
+// so prefer Bus.Trace for dispatchers built on a Bus.
+func Traced(dispatcher Dispatcher, tracer Tracer) Dispatcher {
+	return &tracedDispatcher{dispatcher, tracer}
+}
+
+type tracedDispatcher struct {
+	Dispatcher
+	tracer Tracer
+}
+
+// Dispatch implements the Dispatcher interface
+func (dispatcher *tracedDispatcher) Dispatch(e Event) (bool, error) {
+	started := time.Now()
+	consumed, err := dispatcher.Dispatcher.Dispatch(e)
+	dispatcher.tracer.TraceDispatch(DispatchTrace{
+		Type:     fmt.Sprintf("%T", e),
+		Event:    e,
+		Start:    started,
+		Duration: time.Since(started),
+		Consumed: consumed,
+		Err:      err,
+	})
+	return consumed, err
+}
+
+// LogTracer writes every dispatch to a structured logger.
+// Successful dispatches are logged at Debug level, failed ones at Error level.
+type LogTracer struct {
+	Logger *slog.Logger
+}
+
+// TraceDispatch implements the Tracer interface
+func (tracer *LogTracer) TraceDispatch(trace DispatchTrace) {
+	level := slog.LevelDebug
+	if trace.Err != nil {
+		level = slog.LevelError
+	}
+	if !tracer.Logger.Enabled(context.Background(), level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("event", trace.Type),
+		slog.Int("listeners", len(trace.Listeners)),
+		slog.Duration("duration", trace.Duration),
+		slog.Bool("consumed", trace.Consumed),
+	}
+	if len(trace.Listeners) > 0 {
+		listeners := make([]any, len(trace.Listeners))
+		for i, listener := range trace.Listeners {
+			listeners[i] = slog.Duration(listener.Listener, listener.Duration)
+		}
+		attrs = append(attrs, slog.Group("timings", listeners...))
+	}
+	if trace.Err != nil {
+		attrs = append(attrs, slog.String("error", trace.Err.Error()))
+	}
+	tracer.Logger.LogAttrs(context.Background(), level, "dispatch", attrs...)
+}
+
+// Inspector keeps a rolling buffer of recent dispatches plus running statistics per event type,
+// for debug overlays and tools to query while the application is running. Safe to query from any goroutine.
+type Inspector struct {
+	mutex  sync.Mutex
+	recent []DispatchTrace
+	next   int
+	full   bool
+	stats  map[string]*EventStats
+}
+
+// EventStats are the running statistics of an event type
+type EventStats struct {
+	Type          string
+	Dispatches    int
+	Errors        int
+	Total         time.Duration // Time spent dispatching across every dispatch
+	Max           time.Duration // The slowest dispatch
+	MaxListeners  int           // The most listeners called in one dispatch
+	SlowestCaller string        // The listener that took the longest in a single call
+	SlowestCall   time.Duration
+}
+
+// Average returns the mean time spent per dispatch
+func (stats EventStats) Average() time.Duration {
+	if stats.Dispatches == 0 {
+		return 0
+	}
+	return stats.Total / time.Duration(stats.Dispatches)
+}
+
+// NewInspector creates an inspector remembering the last capacity dispatches
+func NewInspector(capacity int) *Inspector {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Inspector{recent: make([]DispatchTrace, capacity), stats: make(map[string]*EventStats)}
+}
+
+// TraceDispatch implements the Tracer interface
+func (inspector *Inspector) TraceDispatch(trace DispatchTrace) {
+	inspector.mutex.Lock()
+	defer inspector.mutex.Unlock()
+
+	inspector.recent[inspector.next] = trace
+	inspector.next = (inspector.next + 1) % len(inspector.recent)
+	if inspector.next == 0 {
+		inspector.full = true
+	}
+
+	stats, ok := inspector.stats[trace.Type]
+	if !ok {
+		stats = &EventStats{Type: trace.Type}
+		inspector.stats[trace.Type] = stats
+	}
+	stats.Dispatches++
+	stats.Total += trace.Duration
+	if trace.Err != nil {
+		stats.Errors++
+	}
+	if trace.Duration > stats.Max {
+		stats.Max = trace.Duration
+	}
+	if len(trace.Listeners) > stats.MaxListeners {
+		stats.MaxListeners = len(trace.Listeners)
+	}
+	for _, listener := range trace.Listeners {
+		if listener.Duration > stats.SlowestCall {
+			stats.SlowestCall = listener.Duration
+			stats.SlowestCaller = listener.Listener
+		}
+	}
+}
+
+// Recent returns the buffered dispatches, oldest first
+func (inspector *Inspector) Recent() []DispatchTrace {
+	inspector.mutex.Lock()
+	defer inspector.mutex.Unlock()
+	if !inspector.full {
+		return append([]DispatchTrace(nil), inspector.recent[:inspector.next]...)
+	}
+	recent := append([]DispatchTrace(nil), inspector.recent[inspector.next:]...)
+	return append(recent, inspector.recent[:inspector.next]...)
+}
+
+// Stats returns the statistics of every event type seen, most time consuming first
+func (inspector *Inspector) Stats() []EventStats {
+	inspector.mutex.Lock()
+	stats := make([]EventStats, 0, len(inspector.stats))
+	for _, s := range inspector.stats {
+		stats = append(stats, *s)
+	}
+	inspector.mutex.Unlock()
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Total > stats[j].Total })
+	return stats
+}
+
+// Reset clears the buffer and statistics
+func (inspector *Inspector) Reset() {
+	inspector.mutex.Lock()
+	defer inspector.mutex.Unlock()
+	inspector.recent = make([]DispatchTrace, len(inspector.recent))
+	inspector.next = 0
+	inspector.full = false
+	inspector.stats = make(map[string]*EventStats)
+}
+
+// WriteReport writes the statistics as a human readable table, i.e. for a CLI or console command
+func (inspector *Inspector) WriteReport(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "EVENT\tCOUNT\tERRORS\tAVG\tMAX\tLISTENERS\tSLOWEST LISTENER")
+	for _, stats := range inspector.Stats() {
+		fmt.Fprintf(table, "%s\t%d\t%d\t%v\t%v\t%d\t%s (%v)\n",
+			stats.Type, stats.Dispatches, stats.Errors, stats.Average(), stats.Max, stats.MaxListeners, stats.SlowestCaller, stats.SlowestCall)
+	}
+	return table.Flush()
+}
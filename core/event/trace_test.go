@@ -0,0 +1,141 @@
+package event
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBusTrace(t *testing.T) {
+	var bus Bus
+	var traces []DispatchTrace
+	sub := bus.Trace(TracerFunc(func(trace DispatchTrace) { traces = append(traces, trace) }))
+	Subscribe(&bus, func(e testEvent) {})
+	SubscribeErr(&bus, func(e testEvent) error { return errTest })
+
+	_, _ = bus.Dispatch(testEvent{N: 1})
+	var e Event
+	_, _ = Publish(&bus, e)
+	sub.Cancel()
+	_, _ = bus.Dispatch(testEvent{N: 2})
+
+	if len(traces) != 1 {
+		t.Fatalf("traced %d dispatches, want only the one while attached, not the nil event", len(traces))
+	}
+	trace := traces[0]
+	if trace.Type != "event.testEvent" || trace.Event != (testEvent{N: 1}) || trace.Err == nil || trace.Consumed {
+		t.Errorf("trace is %+v", trace)
+	}
+	if len(trace.Listeners) != 2 || trace.Listeners[0].Err != nil || trace.Listeners[1].Err == nil ||
+		!strings.Contains(trace.Listeners[0].Listener, "TestBusTrace") {
+		t.Errorf("listener traces are %+v, want both handlers in order with the second one failing", trace.Listeners)
+	}
+}
+
+func TestTraced(t *testing.T) {
+	target := &testDispatcher{}
+	Subscribe(&target.bus, func(e testCancellable) { e.StopPropagation() })
+	var traces []DispatchTrace
+	traced := Traced(target, TracerFunc(func(trace DispatchTrace) { traces = append(traces, trace) }))
+	if consumed, err := traced.Dispatch(testCancellable{&Propagation{}}); !consumed || err != nil {
+		t.Fatalf("Dispatch returned %v, %v", consumed, err)
+	}
+	if len(traces) != 1 || traces[0].Type != "event.testCancellable" || !traces[0].Consumed {
+		t.Errorf("traced %+v", traces)
+	}
+}
+
+func TestLogTracer(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tracer := &LogTracer{Logger: logger}
+	tracer.TraceDispatch(DispatchTrace{Type: "ok", Listeners: []ListenerTrace{{Listener: "fast", Duration: time.Millisecond}}})
+	tracer.TraceDispatch(DispatchTrace{Type: "failed", Err: errTest})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %q, want one line per dispatch", out.String())
+	}
+	if !strings.Contains(lines[0], "level=DEBUG") || !strings.Contains(lines[0], "event=ok") || !strings.Contains(lines[0], "timings.fast=1ms") {
+		t.Errorf("successful dispatch logged as %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "level=ERROR") || !strings.Contains(lines[1], `error="test failure"`) {
+		t.Errorf("failed dispatch logged as %q", lines[1])
+	}
+
+	out.Reset()
+	tracer.Logger = slog.New(slog.NewTextHandler(&out, nil))
+	tracer.TraceDispatch(DispatchTrace{Type: "ok"})
+	if out.Len() != 0 {
+		t.Errorf("successful dispatch logged above Debug level: %q", out.String())
+	}
+}
+
+func TestInspectorWrapsAround(t *testing.T) {
+	inspector := NewInspector(3)
+	types := func() []string {
+		var names []string
+		for _, trace := range inspector.Recent() {
+			names = append(names, trace.Type)
+		}
+		return names
+	}
+	inspector.TraceDispatch(DispatchTrace{Type: "a"})
+	inspector.TraceDispatch(DispatchTrace{Type: "b"})
+	if got := strings.Join(types(), ""); got != "ab" {
+		t.Errorf("before filling up Recent is %q, want ab", got)
+	}
+	inspector.TraceDispatch(DispatchTrace{Type: "c"})
+	if got := strings.Join(types(), ""); got != "abc" {
+		t.Errorf("once full Recent is %q, want abc", got)
+	}
+	inspector.TraceDispatch(DispatchTrace{Type: "d"})
+	inspector.TraceDispatch(DispatchTrace{Type: "e"})
+	if got := strings.Join(types(), ""); got != "cde" {
+		t.Errorf("after wrapping around Recent is %q, want the newest three oldest first, cde", got)
+	}
+
+	inspector.Reset()
+	if len(inspector.Recent()) != 0 || len(inspector.Stats()) != 0 {
+		t.Error("Reset left traces or statistics behind")
+	}
+}
+
+func TestInspectorStats(t *testing.T) {
+	inspector := NewInspector(1)
+	inspector.TraceDispatch(DispatchTrace{Type: "cheap", Duration: time.Millisecond})
+	inspector.TraceDispatch(DispatchTrace{Type: "costly", Duration: 4 * time.Millisecond, Listeners: []ListenerTrace{
+		{Listener: "quick", Duration: time.Millisecond},
+		{Listener: "slow", Duration: 3 * time.Millisecond},
+	}})
+	inspector.TraceDispatch(DispatchTrace{Type: "costly", Duration: 2 * time.Millisecond, Err: errTest, Listeners: []ListenerTrace{
+		{Listener: "quick", Duration: 2 * time.Millisecond},
+	}})
+
+	stats := inspector.Stats()
+	if len(stats) != 2 || stats[0].Type != "costly" || stats[1].Type != "cheap" {
+		t.Fatalf("Stats is %+v, want costly then cheap", stats)
+	}
+	want := EventStats{Type: "costly", Dispatches: 2, Errors: 1, Total: 6 * time.Millisecond, Max: 4 * time.Millisecond,
+		MaxListeners: 2, SlowestCaller: "slow", SlowestCall: 3 * time.Millisecond}
+	if stats[0] != want {
+		t.Errorf("costly stats are %+v, want %+v", stats[0], want)
+	}
+	if stats[0].Average() != 3*time.Millisecond || (EventStats{}).Average() != 0 {
+		t.Errorf("Average is %v, want 3ms", stats[0].Average())
+	}
+
+	var report bytes.Buffer
+	if err := inspector.WriteReport(&report); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "EVENT") || !strings.HasPrefix(lines[1], "costly") || !strings.HasPrefix(lines[2], "cheap") {
+		t.Fatalf("report is\n%s", report.String())
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "costly 2 1 3ms 4ms 2 slow (3ms)" {
+		t.Errorf("costly row is %q", lines[1])
+	}
+}
@@ -0,0 +1,119 @@
+// Package mainthread runs work on the main OS thread, which windowing libraries like GLFW require.
+//
+// Importing it pins the main goroutine to the main OS thread. The goroutine running the application loop binds itself
+// with Bind and runs queued work every frame with Drain; from then on any goroutine can hand it work with Call and CallSync.
+// Before anything is bound, the main goroutine itself can still use CallSync, i.e. to set up the window before running.
+package mainthread
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+func init() {
+	// init runs on the main goroutine, and locking it here keeps it on the main OS thread for the life of the program
+	runtime.LockOSThread()
+	mainID = goroutineID()
+}
+
+var (
+	mainID int64 // ID of the main goroutine, the one locked to the main OS thread
+	mutex  sync.Mutex
+	bound  int64 // ID of the goroutine draining the queue, 0 when there is none
+	tasks  []func()
+)
+
+// Bind makes the calling goroutine the main one, which should be the one main was called on.
+// Call the returned func to unbind it, which runs any work still queued
+func Bind() (unbind func()) {
+	mutex.Lock()
+	bound = goroutineID()
+	mutex.Unlock()
+	return func() {
+		mutex.Lock()
+		bound = 0
+		pending := tasks
+		tasks = nil
+		mutex.Unlock()
+		for _, task := range pending {
+			task()
+		}
+	}
+}
+
+// OnMain returns if the caller is running on the bound main goroutine
+func OnMain() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return bound != 0 && bound == goroutineID()
+}
+
+// Call queues fn to run on the main goroutine the next time it drains. Safe to call from any goroutine.
+// When nothing is bound there is no loop to run it, so it runs right away
+func Call(fn func()) {
+	mutex.Lock()
+	if bound == 0 {
+		mutex.Unlock()
+		fn()
+		return
+	}
+	tasks = append(tasks, fn)
+	mutex.Unlock()
+}
+
+// NotBoundError is the error CallSync returns when no goroutine is bound, so there is no main thread to run work on
+type NotBoundError struct{}
+
+// Error implements the error interface
+func (err NotBoundError) Error() string {
+	return "no goroutine is bound to the main thread, work for it can only run while the application runs"
+}
+
+// CallSync runs fn on the main goroutine and waits for it to finish, returning its error.
+// It runs right away when called from the bound goroutine, or from the main goroutine while nothing is bound.
+// When nothing is bound and the caller is any other goroutine, fn is not run at all and a NotBoundError returned,
+// as running it on whatever thread the caller is on is exactly what the main thread exists to prevent
+func CallSync(fn func() error) error {
+	mutex.Lock()
+	if bound == 0 {
+		mutex.Unlock()
+		if goroutineID() == mainID {
+			return fn()
+		}
+		return NotBoundError{}
+	}
+	if bound == goroutineID() {
+		mutex.Unlock()
+		return fn()
+	}
+	done := make(chan error, 1)
+	tasks = append(tasks, func() { done <- fn() })
+	mutex.Unlock()
+	return <-done
+}
+
+// Drain runs everything queued so far, in the order it was queued. Call it from the main goroutine
+func Drain() {
+	mutex.Lock()
+	pending := tasks
+	tasks = nil
+	mutex.Unlock()
+	for _, task := range pending {
+		task()
+	}
+}
+
+// goroutineID returns the ID of the calling goroutine, read from the header of its stack trace.
+// Go deliberately hides it, but it is the only portable way to tell which goroutine is calling
+func goroutineID() int64 {
+	var buffer [64]byte
+	stack := buffer[:runtime.Stack(buffer[:], false)]
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i >= 0 {
+		stack = stack[:i]
+	}
+	id, _ := strconv.ParseInt(string(stack), 10, 64)
+	return id
+}
@@ -0,0 +1,72 @@
+package mainthread
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCallSyncUnbound(t *testing.T) {
+	ran := false
+	err := CallSync(func() error {
+		ran = true
+		return nil
+	})
+	if !errors.As(err, new(NotBoundError)) {
+		t.Errorf("CallSync without a main thread returned %v, want a NotBoundError", err)
+	}
+	if ran {
+		t.Error("CallSync ran fn without a main thread")
+	}
+}
+
+func TestCallSyncFromOtherGoroutine(t *testing.T) {
+	defer Bind()()
+	result := make(chan error, 1)
+	onMain := make(chan bool, 1)
+	go func() {
+		result <- CallSync(func() error {
+			onMain <- OnMain()
+			return errors.New("from main")
+		})
+	}()
+	// Drain until the call has been queued and run
+	for len(onMain) == 0 {
+		Drain()
+	}
+	if !<-onMain {
+		t.Error("fn did not run on the bound goroutine")
+	}
+	if err := <-result; err == nil || err.Error() != "from main" {
+		t.Errorf("CallSync returned %v, want the error of fn", err)
+	}
+}
+
+func TestCallSyncFromMain(t *testing.T) {
+	defer Bind()()
+	ran := false
+	if err := CallSync(func() error {
+		ran = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Error("CallSync from the bound goroutine did not run fn right away")
+	}
+}
+
+func TestCallSyncUnboundOnMain(t *testing.T) {
+	// Tests don't run on the main goroutine, so stand in for it
+	defer func(id int64) { mainID = id }(mainID)
+	mainID = goroutineID()
+	ran := false
+	if err := CallSync(func() error {
+		ran = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Error("CallSync from the main goroutine did not run fn while nothing was bound")
+	}
+}
@@ -1,11 +1,17 @@
 package examples
 
 import (
+	"log"
+	"os"
+
 	"github.com/gjh33/SurrealEngine/core/app"
 )
 
 // CreateABasicApplication is the entry point for the Creating An Application example
 func CreateABasicApplication() {
 	application := app.New("Basic Application", "1.0.0")
-	application.Start()
+	if err := application.Start(); err != nil {
+		log.Println(err.Error())
+	}
+	os.Exit(application.ExitCode())
 }
@@ -21,6 +21,16 @@ type BaseContext struct {
 	Settings
 }
 
+// ActiveSettings returns the context's settings
+func (base *BaseContext) ActiveSettings() Settings {
+	return base.Settings
+}
+
+// SetSettings replaces the context's settings. Call it before Initialize
+func (base *BaseContext) SetSettings(settings Settings) {
+	base.Settings = settings
+}
+
 // Settings are the platform agnostic graphics settings supported by the Surreal Engine
 type Settings struct {
 	DisplayResolution Resolution       // The resolution at which to render the content
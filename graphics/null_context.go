@@ -0,0 +1,43 @@
+package graphics
+
+import (
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+// NullContext is a graphics context that renders nothing, bound to a win.NullWindow.
+// It lets the engine run on machines without a GPU or display.
+type NullContext struct {
+	BaseContext
+}
+
+// NewNullContext returns a NullContext bound to the given window instead of a new win.NullWindow,
+// i.e. a win.FakeWindow to script user input in tests
+func NewNullContext(window win.Window) *NullContext {
+	nullcxt := &NullContext{}
+	nullcxt.State.Window = window
+	return nullcxt
+}
+
+// Initialize implements the Context interface
+func (nullcxt *NullContext) Initialize() error {
+	window := nullcxt.State.Window
+	if window == nil {
+		window = &win.NullWindow{}
+	}
+	if err := window.Initialize(); err != nil {
+		return err
+	}
+	nullcxt.State.Window = window
+	nullcxt.State.Initialized = true
+	return nil
+}
+
+// Window implements the Context interface
+func (nullcxt *NullContext) Window() win.Window {
+	return nullcxt.State.Window
+}
+
+// IsInitialized implements the Context interface
+func (nullcxt *NullContext) IsInitialized() bool {
+	return nullcxt.Initialized
+}
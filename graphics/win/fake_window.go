@@ -0,0 +1,174 @@
+package win
+
+import (
+	"time"
+)
+
+// FakeWindow is a NullWindow for unit tests, with hooks to script what a user or the OS would do to a real window.
+// Like a real window, input only arrives between Create and Close.
+// The hooks change state and dispatch the same events a real window's callbacks do, i.e.
+//
+//	window.SimulateResize(Size{800, 600}) // WindowResizedEvent
+//	window.SimulateFocusLost()            // WindowFocusLostEvent
+//	window.SimulateKeyPress(KeySpace, 0)  // KeyPressedEvent
+//	window.SimulateClick(MouseButtonLeft) // MouseButtonPressedEvent, MouseButtonReleasedEvent
+//	window.InjectText("héllo")            // TextInputEvent, while text input is on
+//	window.RequestClose()                 // The run loop sees ShouldClose and closes the window
+type FakeWindow struct {
+	NullWindow
+	Clock interface{ Now() time.Time } // When simulated presses happen, for counting multi clicks. The system time when nil
+
+	closeRequested bool
+	clicks         clickCounter
+	modifiers      Modifiers // Held as of the last simulated key, for simulated mouse buttons
+}
+
+// Initialize implements Window interface
+func (window *FakeWindow) Initialize() error {
+	window.baseEvent = BaseWindowEvent{window}
+	return window.NullWindow.Initialize()
+}
+
+// Close implements Window interface
+// Listeners can cancel the WindowCloseRequestedEvent to keep the window open, which also drops any RequestClose
+func (window *FakeWindow) Close() error {
+	window.closeRequested = false
+	return window.NullWindow.Close()
+}
+
+// ShouldClose implements window interface
+// True once RequestClose is called, until the window is closed or the close is cancelled
+func (window *FakeWindow) ShouldClose() bool {
+	return window.Created && window.closeRequested
+}
+
+// RequestClose acts as if the user pressed the window's close button
+func (window *FakeWindow) RequestClose() {
+	window.closeRequested = true
+}
+
+// SimulateResize acts as if the user resized the window
+func (window *FakeWindow) SimulateResize(size Size) {
+	_ = window.NullWindow.Resize(size)
+}
+
+// SimulateMove acts as if the user dragged the window to a new location
+func (window *FakeWindow) SimulateMove(location Location) {
+	_ = window.NullWindow.SetLocation(location)
+}
+
+// SimulateFocus acts as if the user switched to the window
+func (window *FakeWindow) SimulateFocus() {
+	_ = window.NullWindow.Focus()
+}
+
+// SimulateFocusLost acts as if the user switched to another window
+func (window *FakeWindow) SimulateFocusLost() {
+	if window.Focused {
+		window.Focused = false
+		if window.Created {
+			_, _ = window.Dispatch(WindowFocusLostEvent{window.baseEvent})
+		}
+	}
+}
+
+// SimulateIconify acts as if the user minimized the window
+func (window *FakeWindow) SimulateIconify() {
+	_ = window.NullWindow.Iconify()
+}
+
+// SimulateRestore acts as if the user brought the window back from being minimized
+func (window *FakeWindow) SimulateRestore() {
+	_ = window.NullWindow.Restore()
+}
+
+// SimulateKeyPress acts as if the user pressed a key
+func (window *FakeWindow) SimulateKeyPress(key Key, modifiers Modifiers) {
+	if !window.Created {
+		return
+	}
+	window.modifiers = modifiers
+	_, _ = window.Dispatch(KeyPressedEvent{window.baseEvent, key, 0, modifiers})
+}
+
+// SimulateKeyRepeat acts as if the user kept a key held long enough for it to repeat
+func (window *FakeWindow) SimulateKeyRepeat(key Key, modifiers Modifiers) {
+	if !window.Created {
+		return
+	}
+	window.modifiers = modifiers
+	_, _ = window.Dispatch(KeyRepeatedEvent{window.baseEvent, key, 0, modifiers})
+}
+
+// SimulateKeyRelease acts as if the user let go of a key
+func (window *FakeWindow) SimulateKeyRelease(key Key, modifiers Modifiers) {
+	if !window.Created {
+		return
+	}
+	window.modifiers = modifiers
+	_, _ = window.Dispatch(KeyReleasedEvent{window.baseEvent, key, 0, modifiers})
+}
+
+// SimulateCursorMove acts as if the user moved the cursor to a position in window coordinates.
+// The framebuffer is the same size as the window, as there is no high resolution display
+func (window *FakeWindow) SimulateCursorMove(position Position) {
+	if !window.Created {
+		return
+	}
+	oldPosition := window.State.Cursor
+	window.State.Cursor = position
+	_, _ = window.Dispatch(CursorMovedEvent{window.baseEvent, position, position, position.Sub(oldPosition)})
+}
+
+// SimulateCursorEnter acts as if the user moved the cursor onto the window
+func (window *FakeWindow) SimulateCursorEnter() {
+	if !window.Created {
+		return
+	}
+	window.State.Hovered = true
+	_, _ = window.Dispatch(CursorEnteredEvent{window.baseEvent})
+}
+
+// SimulateCursorLeave acts as if the user moved the cursor off the window
+func (window *FakeWindow) SimulateCursorLeave() {
+	if !window.Created {
+		return
+	}
+	window.State.Hovered = false
+	_, _ = window.Dispatch(CursorLeftEvent{window.baseEvent})
+}
+
+// SimulateMousePress acts as if the user pressed a mouse button at the cursor. Presses in quick succession count as multi clicks
+func (window *FakeWindow) SimulateMousePress(button MouseButton) {
+	if !window.Created {
+		return
+	}
+	now := time.Now()
+	if window.Clock != nil {
+		now = window.Clock.Now()
+	}
+	clicks := window.clicks.press(button, window.State.Cursor, now)
+	_, _ = window.Dispatch(MouseButtonPressedEvent{window.baseEvent, button, window.modifiers, window.State.Cursor, clicks})
+}
+
+// SimulateMouseRelease acts as if the user let go of a mouse button at the cursor
+func (window *FakeWindow) SimulateMouseRelease(button MouseButton) {
+	if !window.Created {
+		return
+	}
+	_, _ = window.Dispatch(MouseButtonReleasedEvent{window.baseEvent, button, window.modifiers, window.State.Cursor})
+}
+
+// SimulateClick presses and releases a mouse button at the cursor
+func (window *FakeWindow) SimulateClick(button MouseButton) {
+	window.SimulateMousePress(button)
+	window.SimulateMouseRelease(button)
+}
+
+// SimulateScroll acts as if the user turned the mouse wheel or scrolled on a trackpad
+func (window *FakeWindow) SimulateScroll(offset Position) {
+	if !window.Created {
+		return
+	}
+	_, _ = window.Dispatch(MouseScrolledEvent{window.baseEvent, offset})
+}
@@ -0,0 +1,76 @@
+package win_test
+
+import (
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/graphics/win"
+	"github.com/gjh33/SurrealEngine/graphics/win/wintest"
+)
+
+func TestFakeWindowConformance(t *testing.T) {
+	if err := wintest.TestWindow(&win.FakeWindow{}); err != nil {
+		t.Error(err)
+	}
+}
+
+// simulateInput runs every input hook of a fake window once
+func simulateInput(window *win.FakeWindow) {
+	window.SimulateKeyPress(win.KeyA, 0)
+	window.SimulateKeyRepeat(win.KeyA, 0)
+	window.SimulateKeyRelease(win.KeyA, 0)
+	window.SimulateCursorEnter()
+	window.SimulateCursorMove(win.Position{X: 10, Y: 20})
+	window.SimulateClick(win.MouseButtonLeft)
+	window.SimulateScroll(win.Position{Y: 1})
+	window.SimulateCursorLeave()
+}
+
+func TestFakeWindowInputNeedsCreation(t *testing.T) {
+	window := &win.FakeWindow{}
+	var got []event.Event
+	defer event.Observe(window.Bus(), func(e event.Event) { got = append(got, e) }).Cancel()
+	if err := window.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	simulateInput(window)
+	if inputEvents(got) != 0 {
+		t.Errorf("input before Create dispatched %v", got)
+	}
+	if window.CursorPosition() != (win.Position{}) {
+		t.Errorf("input before Create moved the cursor to %v", window.CursorPosition())
+	}
+
+	if err := window.Create(); err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	simulateInput(window)
+	if count := inputEvents(got); count != 9 {
+		t.Errorf("input while created dispatched %d events, want 9: %v", count, got)
+	}
+
+	if err := window.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	simulateInput(window)
+	if inputEvents(got) != 0 {
+		t.Errorf("input after Close dispatched %v", got)
+	}
+}
+
+// inputEvents counts the keyboard and mouse events among events
+func inputEvents(events []event.Event) int {
+	count := 0
+	for _, e := range events {
+		switch e.(type) {
+		case win.KeyPressedEvent, win.KeyRepeatedEvent, win.KeyReleasedEvent,
+			win.CursorEnteredEvent, win.CursorMovedEvent, win.CursorLeftEvent,
+			win.MouseButtonPressedEvent, win.MouseButtonReleasedEvent, win.MouseScrolledEvent:
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,118 @@
+package win
+
+import (
+	"sync"
+)
+
+// Keyboard is the state of the keyboard, for polling instead of listening for key events.
+// Subscribe it to a window, and call Update once a frame; the application does both for its window.
+// State only changes in Update, so it is stable for the whole frame. Safe to read from any goroutine
+type Keyboard struct {
+	mutex     sync.RWMutex
+	down      [KeyCount]bool
+	pressed   [KeyCount]bool // Went down this frame
+	released  [KeyCount]bool // Went up this frame
+	modifiers Modifiers
+
+	pending         []keyChange // Received since the last Update
+	pendingModifier Modifiers
+}
+
+// keyChange is a key going up or down
+type keyChange struct {
+	key  Key
+	down bool
+}
+
+// IsDown returns if the key is held down
+func (keyboard *Keyboard) IsDown(key Key) bool {
+	if key < 0 || key >= KeyCount {
+		return false
+	}
+	keyboard.mutex.RLock()
+	defer keyboard.mutex.RUnlock()
+	return keyboard.down[key]
+}
+
+// WasPressedThisFrame returns if the key went down this frame. It may already be back up, see IsDown
+func (keyboard *Keyboard) WasPressedThisFrame(key Key) bool {
+	if key < 0 || key >= KeyCount {
+		return false
+	}
+	keyboard.mutex.RLock()
+	defer keyboard.mutex.RUnlock()
+	return keyboard.pressed[key]
+}
+
+// WasReleasedThisFrame returns if the key went up this frame
+func (keyboard *Keyboard) WasReleasedThisFrame(key Key) bool {
+	if key < 0 || key >= KeyCount {
+		return false
+	}
+	keyboard.mutex.RLock()
+	defer keyboard.mutex.RUnlock()
+	return keyboard.released[key]
+}
+
+// Modifiers returns the modifier keys held as of the last key event
+func (keyboard *Keyboard) Modifiers() Modifiers {
+	keyboard.mutex.RLock()
+	defer keyboard.mutex.RUnlock()
+	return keyboard.modifiers
+}
+
+// Update applies the key events received since the last call, starting a new frame
+func (keyboard *Keyboard) Update() {
+	keyboard.mutex.Lock()
+	defer keyboard.mutex.Unlock()
+	keyboard.pressed = [KeyCount]bool{}
+	keyboard.released = [KeyCount]bool{}
+	for _, change := range keyboard.pending {
+		if change.down {
+			keyboard.pressed[change.key] = true
+		} else if keyboard.down[change.key] || keyboard.pressed[change.key] {
+			keyboard.released[change.key] = true
+		}
+		keyboard.down[change.key] = change.down
+	}
+	keyboard.pending = keyboard.pending[:0]
+	keyboard.modifiers = keyboard.pendingModifier
+}
+
+// OnKeyPressed implements the KeyPressedListener interface
+func (keyboard *Keyboard) OnKeyPressed(e KeyPressedEvent) {
+	keyboard.change(e.Key, true, e.Modifiers)
+}
+
+// OnKeyReleased implements the KeyReleasedListener interface
+func (keyboard *Keyboard) OnKeyReleased(e KeyReleasedEvent) {
+	keyboard.change(e.Key, false, e.Modifiers)
+}
+
+// OnWindowFocusLost implements the WindowFocusLostListener interface
+// Keys let go of while another window has focus are never reported, so everything is released
+func (keyboard *Keyboard) OnWindowFocusLost(e WindowFocusLostEvent) {
+	keyboard.mutex.Lock()
+	defer keyboard.mutex.Unlock()
+	held := keyboard.down
+	for _, change := range keyboard.pending {
+		held[change.key] = change.down
+	}
+	for key := Key(0); key < KeyCount; key++ {
+		if held[key] {
+			keyboard.pending = append(keyboard.pending, keyChange{key, false})
+		}
+	}
+	keyboard.pendingModifier = 0
+}
+
+// change records a key going up or down
+func (keyboard *Keyboard) change(key Key, down bool, modifiers Modifiers) {
+	if key < 0 || key >= KeyCount {
+		return
+	}
+	keyboard.mutex.Lock()
+	defer keyboard.mutex.Unlock()
+	keyboard.pending = append(keyboard.pending, keyChange{key, down})
+	keyboard.pendingModifier = modifiers
+}
@@ -0,0 +1,117 @@
+package win_test
+
+import (
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+// newKeyboard returns a keyboard subscribed to a created fake window
+func newKeyboard(t *testing.T) (*win.Keyboard, *win.FakeWindow) {
+	t.Helper()
+	window := &win.FakeWindow{}
+	if err := window.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := window.Create(); err != nil {
+		t.Fatal(err)
+	}
+	keyboard := new(win.Keyboard)
+	subscription, err := window.Subscribe(keyboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(subscription.Cancel)
+	return keyboard, window
+}
+
+func TestKeyboardPressAndHold(t *testing.T) {
+	keyboard, window := newKeyboard(t)
+	window.SimulateKeyPress(win.KeyW, win.ModShift)
+	if keyboard.IsDown(win.KeyW) {
+		t.Error("the key is down before Update")
+	}
+
+	keyboard.Update()
+	if !keyboard.IsDown(win.KeyW) || !keyboard.WasPressedThisFrame(win.KeyW) {
+		t.Error("the key is not down and pressed in the frame it went down")
+	}
+	if keyboard.Modifiers() != win.ModShift {
+		t.Errorf("Modifiers is %v, want shift", keyboard.Modifiers())
+	}
+
+	keyboard.Update()
+	if !keyboard.IsDown(win.KeyW) {
+		t.Error("the key is not held down the frame after")
+	}
+	if keyboard.WasPressedThisFrame(win.KeyW) {
+		t.Error("WasPressedThisFrame did not reset on the next frame")
+	}
+
+	window.SimulateKeyRelease(win.KeyW, 0)
+	keyboard.Update()
+	if keyboard.IsDown(win.KeyW) || !keyboard.WasReleasedThisFrame(win.KeyW) {
+		t.Error("the key is not up and released in the frame it went up")
+	}
+	keyboard.Update()
+	if keyboard.WasReleasedThisFrame(win.KeyW) {
+		t.Error("WasReleasedThisFrame did not reset on the next frame")
+	}
+}
+
+func TestKeyboardPressAndReleaseInOneFrame(t *testing.T) {
+	keyboard, window := newKeyboard(t)
+	window.SimulateKeyPress(win.KeySpace, 0)
+	window.SimulateKeyRelease(win.KeySpace, 0)
+	keyboard.Update()
+	if !keyboard.WasPressedThisFrame(win.KeySpace) || !keyboard.WasReleasedThisFrame(win.KeySpace) {
+		t.Error("a tap within one frame is not both pressed and released")
+	}
+	if keyboard.IsDown(win.KeySpace) {
+		t.Error("a tap within one frame left the key down")
+	}
+}
+
+func TestKeyboardOutOfRangeKeys(t *testing.T) {
+	keyboard, window := newKeyboard(t)
+	for _, key := range []win.Key{-1, win.KeyCount, win.KeyCount + 10} {
+		window.SimulateKeyPress(key, 0)
+		keyboard.Update()
+		if keyboard.IsDown(key) || keyboard.WasPressedThisFrame(key) || keyboard.WasReleasedThisFrame(key) {
+			t.Errorf("key %d is reported even though it is out of range", key)
+		}
+	}
+}
+
+func TestKeyboardReleasesOnFocusLost(t *testing.T) {
+	keyboard, window := newKeyboard(t)
+	window.SimulateKeyPress(win.KeyA, win.ModControl)
+	keyboard.Update()
+	// Pressed after the last update, so only pending when focus goes
+	window.SimulateKeyPress(win.KeyD, win.ModControl)
+	window.SimulateFocusLost()
+	keyboard.Update()
+	for _, key := range []win.Key{win.KeyA, win.KeyD} {
+		if keyboard.IsDown(key) {
+			t.Errorf("%v is still down after focus was lost", key)
+		}
+		if !keyboard.WasReleasedThisFrame(key) {
+			t.Errorf("%v was not released when focus was lost", key)
+		}
+	}
+	if keyboard.Modifiers() != 0 {
+		t.Errorf("Modifiers is %v after focus was lost, want none", keyboard.Modifiers())
+	}
+}
+
+func TestKeyboardIgnoresClosedWindow(t *testing.T) {
+	keyboard, window := newKeyboard(t)
+	if err := window.Close(); err != nil {
+		t.Fatal(err)
+	}
+	window.SimulateKeyPress(win.KeyA, 0)
+	keyboard.Update()
+	if keyboard.IsDown(win.KeyA) {
+		t.Error("a key pressed on a closed window is down")
+	}
+}
@@ -0,0 +1,307 @@
+package win
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// MouseButton is a mouse button. Codes are the engine's own, so every window backend reports the same ones
+type MouseButton int
+
+// Declaring MouseButton enum values
+const (
+	MouseButtonLeft MouseButton = iota
+	MouseButtonRight
+	MouseButtonMiddle
+	MouseButton4
+	MouseButton5
+	MouseButton6
+	MouseButton7
+	MouseButton8
+
+	// MouseButtonCount is the number of mouse buttons, for sizing tables indexed by MouseButton
+	MouseButtonCount
+)
+
+// String implements the fmt.Stringer interface
+func (button MouseButton) String() string {
+	switch button {
+	case MouseButtonLeft:
+		return "Left"
+	case MouseButtonRight:
+		return "Right"
+	case MouseButtonMiddle:
+		return "Middle"
+	}
+	if button > MouseButtonMiddle && button < MouseButtonCount {
+		return fmt.Sprintf("Button%d", int(button)+1)
+	}
+	return fmt.Sprintf("MouseButton(%d)", int(button))
+}
+
+// Position is a point or offset in a window, in screen coordinates from the top left of its content.
+// It is fractional because high resolution displays and trackpads report sub pixel positions
+type Position struct {
+	X float64
+	Y float64
+}
+
+// Add returns the sum of two positions
+func (position Position) Add(other Position) Position {
+	return Position{position.X + other.X, position.Y + other.Y}
+}
+
+// Sub returns the offset from other to position
+func (position Position) Sub(other Position) Position {
+	return Position{position.X - other.X, position.Y - other.Y}
+}
+
+// Scale returns the position in a space scaled by the given factors, i.e. from window to framebuffer coordinates
+func (position Position) Scale(x float64, y float64) Position {
+	return Position{position.X * x, position.Y * y}
+}
+
+const (
+	// DoubleClickTime is the longest time between presses of a button that count as one multi click
+	DoubleClickTime = 500 * time.Millisecond
+	// DoubleClickDistance is the farthest the cursor can move between presses that count as one multi click
+	DoubleClickDistance = 4.0
+)
+
+// clickCounter counts consecutive presses of a button close together in time and space, for double and triple clicks
+type clickCounter struct {
+	button   MouseButton
+	position Position
+	time     time.Time
+	clicks   int
+}
+
+// press records a press and returns how many clicks in a row it makes
+func (counter *clickCounter) press(button MouseButton, position Position, now time.Time) int {
+	offset := position.Sub(counter.position)
+	if counter.clicks > 0 && button == counter.button && now.Sub(counter.time) <= DoubleClickTime &&
+		math.Hypot(offset.X, offset.Y) <= DoubleClickDistance {
+		counter.clicks++
+	} else {
+		counter.clicks = 1
+	}
+	counter.button, counter.position, counter.time = button, position, now
+	return counter.clicks
+}
+
+// Mouse is the state of the mouse, for polling instead of listening for mouse events.
+// Subscribe it to a window, and call Update once a frame; the application does both for its window.
+// State only changes in Update, so it is stable for the whole frame. Safe to read from any goroutine
+type Mouse struct {
+	mutex       sync.RWMutex
+	down        [MouseButtonCount]bool
+	pressed     [MouseButtonCount]bool // Went down this frame
+	released    [MouseButtonCount]bool // Went up this frame
+	clicks      [MouseButtonCount]int  // Click count of the last press this frame
+	position    Position
+	framebuffer Position
+	delta       Position
+	scroll      Position
+	inside      bool
+
+	pending        []buttonChange // Received since the last Update
+	pendingState   mouseMotion
+	pendingDelta   Position
+	pendingScroll  Position
+	pendingUpdated bool
+}
+
+// buttonChange is a mouse button going up or down
+type buttonChange struct {
+	button MouseButton
+	down   bool
+	clicks int
+}
+
+// mouseMotion is where the cursor is
+type mouseMotion struct {
+	position    Position
+	framebuffer Position
+	inside      bool
+}
+
+// IsDown returns if the button is held down
+func (mouse *Mouse) IsDown(button MouseButton) bool {
+	if button < 0 || button >= MouseButtonCount {
+		return false
+	}
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.down[button]
+}
+
+// WasPressedThisFrame returns if the button went down this frame. It may already be back up, see IsDown
+func (mouse *Mouse) WasPressedThisFrame(button MouseButton) bool {
+	if button < 0 || button >= MouseButtonCount {
+		return false
+	}
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.pressed[button]
+}
+
+// WasReleasedThisFrame returns if the button went up this frame
+func (mouse *Mouse) WasReleasedThisFrame(button MouseButton) bool {
+	if button < 0 || button >= MouseButtonCount {
+		return false
+	}
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.released[button]
+}
+
+// Clicks returns the click count of the button's press this frame, i.e. 2 for a double click. 0 if it wasn't pressed
+func (mouse *Mouse) Clicks(button MouseButton) int {
+	if button < 0 || button >= MouseButtonCount {
+		return 0
+	}
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.clicks[button]
+}
+
+// Position returns where the cursor is, in window coordinates
+func (mouse *Mouse) Position() Position {
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.position
+}
+
+// FramebufferPosition returns where the cursor is, in framebuffer pixels
+func (mouse *Mouse) FramebufferPosition() Position {
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.framebuffer
+}
+
+// Delta returns how far the cursor moved this frame. Raw, unaccelerated motion when the cursor is locked and the platform supports it
+func (mouse *Mouse) Delta() Position {
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.delta
+}
+
+// Scroll returns how far the wheel or trackpad scrolled this frame. Y is vertical, positive away from the user
+func (mouse *Mouse) Scroll() Position {
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.scroll
+}
+
+// IsInside returns if the cursor is over the window's content
+func (mouse *Mouse) IsInside() bool {
+	mouse.mutex.RLock()
+	defer mouse.mutex.RUnlock()
+	return mouse.inside
+}
+
+// Update applies the mouse events received since the last call, starting a new frame
+func (mouse *Mouse) Update() {
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	mouse.pressed = [MouseButtonCount]bool{}
+	mouse.released = [MouseButtonCount]bool{}
+	mouse.clicks = [MouseButtonCount]int{}
+	for _, change := range mouse.pending {
+		if change.down {
+			mouse.pressed[change.button] = true
+			mouse.clicks[change.button] = change.clicks
+		} else if mouse.down[change.button] || mouse.pressed[change.button] {
+			mouse.released[change.button] = true
+		}
+		mouse.down[change.button] = change.down
+	}
+	mouse.pending = mouse.pending[:0]
+	if mouse.pendingUpdated {
+		mouse.position = mouse.pendingState.position
+		mouse.framebuffer = mouse.pendingState.framebuffer
+		mouse.inside = mouse.pendingState.inside
+		mouse.pendingUpdated = false
+	}
+	mouse.delta, mouse.scroll = mouse.pendingDelta, mouse.pendingScroll
+	mouse.pendingDelta, mouse.pendingScroll = Position{}, Position{}
+}
+
+// OnCursorMoved implements the CursorMovedListener interface
+func (mouse *Mouse) OnCursorMoved(e CursorMovedEvent) {
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	mouse.motion()
+	mouse.pendingState.position = e.Position
+	mouse.pendingState.framebuffer = e.FramebufferPosition
+	mouse.pendingDelta = mouse.pendingDelta.Add(e.Delta)
+}
+
+// OnCursorEntered implements the CursorEnteredListener interface
+func (mouse *Mouse) OnCursorEntered(e CursorEnteredEvent) {
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	mouse.motion()
+	mouse.pendingState.inside = true
+}
+
+// OnCursorLeft implements the CursorLeftListener interface
+func (mouse *Mouse) OnCursorLeft(e CursorLeftEvent) {
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	mouse.motion()
+	mouse.pendingState.inside = false
+}
+
+// OnMouseButtonPressed implements the MouseButtonPressedListener interface
+func (mouse *Mouse) OnMouseButtonPressed(e MouseButtonPressedEvent) {
+	mouse.change(e.Button, true, e.Clicks)
+}
+
+// OnMouseButtonReleased implements the MouseButtonReleasedListener interface
+func (mouse *Mouse) OnMouseButtonReleased(e MouseButtonReleasedEvent) {
+	mouse.change(e.Button, false, 0)
+}
+
+// OnMouseScrolled implements the MouseScrolledListener interface
+func (mouse *Mouse) OnMouseScrolled(e MouseScrolledEvent) {
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	mouse.pendingScroll = mouse.pendingScroll.Add(e.Offset)
+}
+
+// OnWindowFocusLost implements the WindowFocusLostListener interface
+// Buttons let go of while another window has focus are never reported, so everything is released
+func (mouse *Mouse) OnWindowFocusLost(e WindowFocusLostEvent) {
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	held := mouse.down
+	for _, change := range mouse.pending {
+		held[change.button] = change.down
+	}
+	for button := MouseButton(0); button < MouseButtonCount; button++ {
+		if held[button] {
+			mouse.pending = append(mouse.pending, buttonChange{button, false, 0})
+		}
+	}
+}
+
+// motion starts pending motion from the current state, the first time it changes in a frame. Call with the lock held
+func (mouse *Mouse) motion() {
+	if !mouse.pendingUpdated {
+		mouse.pendingState = mouseMotion{mouse.position, mouse.framebuffer, mouse.inside}
+		mouse.pendingUpdated = true
+	}
+}
+
+// change records a button going up or down
+func (mouse *Mouse) change(button MouseButton, down bool, clicks int) {
+	if button < 0 || button >= MouseButtonCount {
+		return
+	}
+	mouse.mutex.Lock()
+	defer mouse.mutex.Unlock()
+	mouse.pending = append(mouse.pending, buttonChange{button, down, clicks})
+}
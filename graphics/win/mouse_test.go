@@ -0,0 +1,155 @@
+package win_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+// stepClock is a clock that only moves when told to
+type stepClock struct {
+	now time.Time
+}
+
+func (clock *stepClock) Now() time.Time {
+	return clock.now
+}
+
+// newMouse returns a mouse subscribed to a created fake window, whose presses happen at the time of clock
+func newMouse(t *testing.T, clock *stepClock) (*win.Mouse, *win.FakeWindow) {
+	t.Helper()
+	window := &win.FakeWindow{Clock: clock}
+	if err := window.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := window.Create(); err != nil {
+		t.Fatal(err)
+	}
+	mouse := new(win.Mouse)
+	subscription, err := window.Subscribe(mouse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(subscription.Cancel)
+	return mouse, window
+}
+
+func TestMouseClickCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		wait  time.Duration
+		move  win.Position
+		other bool // Second press is with the right button
+		want  int
+	}{
+		{"double click", win.DoubleClickTime / 2, win.Position{}, false, 2},
+		{"at the time limit", win.DoubleClickTime, win.Position{}, false, 2},
+		{"too slow", win.DoubleClickTime + time.Millisecond, win.Position{}, false, 1},
+		{"within distance", 0, win.Position{X: win.DoubleClickDistance}, false, 2},
+		{"too far", 0, win.Position{X: win.DoubleClickDistance, Y: 1}, false, 1},
+		{"other button", 0, win.Position{}, true, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := &stepClock{time.Unix(1000, 0)}
+			mouse, window := newMouse(t, clock)
+			window.SimulateCursorMove(win.Position{X: 100, Y: 100})
+			window.SimulateClick(win.MouseButtonLeft)
+			mouse.Update()
+			if clicks := mouse.Clicks(win.MouseButtonLeft); clicks != 1 {
+				t.Fatalf("first click counted %d clicks", clicks)
+			}
+
+			clock.now = clock.now.Add(test.wait)
+			window.SimulateCursorMove(win.Position{X: 100, Y: 100}.Add(test.move))
+			button := win.MouseButtonLeft
+			if test.other {
+				button = win.MouseButtonRight
+			}
+			window.SimulateClick(button)
+			mouse.Update()
+			if clicks := mouse.Clicks(button); clicks != test.want {
+				t.Errorf("second click counted %d clicks, want %d", clicks, test.want)
+			}
+		})
+	}
+}
+
+func TestMouseTripleClick(t *testing.T) {
+	clock := &stepClock{time.Unix(1000, 0)}
+	mouse, window := newMouse(t, clock)
+	for i := 1; i <= 3; i++ {
+		window.SimulateClick(win.MouseButtonLeft)
+		clock.now = clock.now.Add(100 * time.Millisecond)
+		mouse.Update()
+		if clicks := mouse.Clicks(win.MouseButtonLeft); clicks != i {
+			t.Errorf("click %d counted %d clicks", i, clicks)
+		}
+	}
+	mouse.Update()
+	if clicks := mouse.Clicks(win.MouseButtonLeft); clicks != 0 {
+		t.Errorf("Clicks is %d the frame after, want 0", clicks)
+	}
+}
+
+func TestMouseDeltaAndScroll(t *testing.T) {
+	mouse, window := newMouse(t, &stepClock{})
+	window.SimulateCursorMove(win.Position{X: 10, Y: 10})
+	window.SimulateCursorMove(win.Position{X: 15, Y: 30})
+	window.SimulateScroll(win.Position{Y: 1})
+	window.SimulateScroll(win.Position{X: -0.5, Y: 2})
+	mouse.Update()
+	if mouse.Position() != (win.Position{X: 15, Y: 30}) {
+		t.Errorf("Position is %v, want the last move", mouse.Position())
+	}
+	if mouse.Delta() != (win.Position{X: 15, Y: 30}) {
+		t.Errorf("Delta is %v, want every move of the frame added up", mouse.Delta())
+	}
+	if mouse.Scroll() != (win.Position{X: -0.5, Y: 3}) {
+		t.Errorf("Scroll is %v, want every scroll of the frame added up", mouse.Scroll())
+	}
+
+	mouse.Update()
+	if mouse.Delta() != (win.Position{}) || mouse.Scroll() != (win.Position{}) {
+		t.Errorf("Delta %v and Scroll %v did not reset on the next frame", mouse.Delta(), mouse.Scroll())
+	}
+	if mouse.Position() != (win.Position{X: 15, Y: 30}) {
+		t.Errorf("Position is %v, want it kept when the cursor doesn't move", mouse.Position())
+	}
+}
+
+func TestMouseEnterAndLeave(t *testing.T) {
+	mouse, window := newMouse(t, &stepClock{})
+	window.SimulateCursorEnter()
+	if mouse.IsInside() {
+		t.Error("the cursor is inside before Update")
+	}
+	mouse.Update()
+	if !mouse.IsInside() {
+		t.Error("the cursor is not inside after entering")
+	}
+	window.SimulateCursorLeave()
+	mouse.Update()
+	if mouse.IsInside() {
+		t.Error("the cursor is inside after leaving")
+	}
+}
+
+func TestMouseReleasesOnFocusLost(t *testing.T) {
+	mouse, window := newMouse(t, &stepClock{})
+	window.SimulateMousePress(win.MouseButtonLeft)
+	mouse.Update()
+	// Pressed after the last update, so only pending when focus goes
+	window.SimulateMousePress(win.MouseButtonRight)
+	window.SimulateFocusLost()
+	mouse.Update()
+	for _, button := range []win.MouseButton{win.MouseButtonLeft, win.MouseButtonRight} {
+		if mouse.IsDown(button) {
+			t.Errorf("%v is still down after focus was lost", button)
+		}
+		if !mouse.WasReleasedThisFrame(button) {
+			t.Errorf("%v was not released when focus was lost", button)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package win
+
+import (
+	"image"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/pkg/errors"
+)
+
+// NullWindow is a window that never reaches the screen. It keeps its state and dispatches the same events as a real
+// window, which makes it suitable for running without a display, i.e. dedicated servers, CI and batch tools.
+type NullWindow struct {
+	BaseWindow
+
+	baseEvent BaseWindowEvent
+}
+
+// Initialize implements Window interface
+func (window *NullWindow) Initialize() error {
+	if window.baseEvent.Window == nil {
+		window.baseEvent = BaseWindowEvent{window}
+	}
+
+	// Set default settings
+	window.Settings.Title = "Surreal Application"
+	window.Settings.Decorated = true
+
+	window.State.Visible = true
+	window.State.Focused = true
+	window.State.Size = Size{1024, 720}
+
+	window.State.Initialized = true
+	_, _ = window.Dispatch(WindowInitializedEvent{window.baseEvent})
+	return nil
+}
+
+// Create implements Window interface
+func (window *NullWindow) Create() error {
+	if err := window.VerifyInitialized(); err != nil {
+		return err
+	}
+	window.State.Created = true
+	_, _ = window.Dispatch(WindowCreatedEvent{window.baseEvent})
+	return nil
+}
+
+// Show implements Window interface
+func (window *NullWindow) Show() error {
+	if !window.Visible {
+		window.State.Visible = true
+		if window.Created {
+			_, _ = window.Dispatch(WindowShownEvent{window.baseEvent})
+		}
+	}
+	return nil
+}
+
+// Hide implements Window interface
+func (window *NullWindow) Hide() error {
+	if window.Visible {
+		window.State.Visible = false
+		if window.Created {
+			_, _ = window.Dispatch(WindowHiddenEvent{window.baseEvent})
+		}
+	}
+	return nil
+}
+
+// Focus implements Window interface
+func (window *NullWindow) Focus() error {
+	if !window.Focused {
+		window.Focused = true
+		if window.Created {
+			_, _ = window.Dispatch(WindowFocusedEvent{window.baseEvent})
+		}
+	}
+	return nil
+}
+
+// Iconify implements Window interface
+func (window *NullWindow) Iconify() error {
+	if !window.Iconified {
+		window.Iconified = true
+		if window.Created {
+			_, _ = window.Dispatch(WindowIconifiedEvent{window.baseEvent})
+		}
+	}
+	return nil
+}
+
+// Restore implements Window interface
+func (window *NullWindow) Restore() error {
+	if window.Iconified {
+		window.Iconified = false
+		if window.Created {
+			_, _ = window.Dispatch(WindowRestoredEvent{window.baseEvent})
+		}
+	}
+	return nil
+}
+
+// Close implements Window interface
+// Listeners can cancel the WindowCloseRequestedEvent to keep the window open
+func (window *NullWindow) Close() error {
+	if window.Created {
+		request := WindowCloseRequestedEvent{window.baseEvent, &event.Propagation{}}
+		_, _ = window.Dispatch(request)
+		if request.Cancelled() {
+			return nil
+		}
+		window.Created = false
+		_, _ = window.Dispatch(WindowClosedEvent{window.baseEvent})
+	}
+	return nil
+}
+
+// Resize implements Window interface
+func (window *NullWindow) Resize(size Size) error {
+	oldSize := window.State.Size
+	window.State.Size = size
+	if window.Created && oldSize != size {
+		_, _ = window.Dispatch(WindowResizedEvent{window.baseEvent, oldSize, size})
+	}
+	return nil
+}
+
+// SetIcons implements Window interface
+func (window *NullWindow) SetIcons(icons []image.Image) error {
+	window.Icons = icons
+	return nil
+}
+
+// SetTitle implements Window interface
+func (window *NullWindow) SetTitle(title string) error {
+	window.Settings.Title = title
+	return nil
+}
+
+// SetLocation implements Window interface
+func (window *NullWindow) SetLocation(location Location) error {
+	oldLocation := window.State.Location
+	window.State.Location = location
+	if window.Created && oldLocation != location {
+		_, _ = window.Dispatch(WindowLocationChangedEvent{window.baseEvent, oldLocation, location})
+	}
+	return nil
+}
+
+// SetResizable implements Window interface
+func (window *NullWindow) SetResizable(resizable bool) error {
+	window.Settings.Resizable = resizable
+	return nil
+}
+
+// SetDecorated implements Window interface
+func (window *NullWindow) SetDecorated(decorated bool) error {
+	window.Settings.Decorated = decorated
+	return nil
+}
+
+// SetFullscreen implements window interface
+func (window *NullWindow) SetFullscreen(fullscreen bool) error {
+	if window.Created {
+		if fullscreen && !window.FullScreen {
+			_, _ = window.Dispatch(WindowFullscreenEvent{window.baseEvent})
+		}
+		if !fullscreen && window.FullScreen {
+			_, _ = window.Dispatch(WindowWindowedEvent{window.baseEvent})
+		}
+	}
+	window.FullScreen = fullscreen
+	return nil
+}
+
+// PollEvents implements Window interface
+// There is no platform to poll, so this does nothing
+func (window *NullWindow) PollEvents() error {
+	return nil
+}
+
+// SetCursorLocked implements Window interface
+func (window *NullWindow) SetCursorLocked(locked bool) error {
+	window.Settings.CursorLocked = locked
+	return nil
+}
+
+// SetCursorHidden implements Window interface
+func (window *NullWindow) SetCursorHidden(hidden bool) error {
+	window.Settings.CursorHidden = hidden
+	return nil
+}
+
+// IsInitialized implements Window interface
+func (window *NullWindow) IsInitialized() bool {
+	return window.Initialized
+}
+
+// IsCreated implements Window interface
+func (window *NullWindow) IsCreated() bool {
+	return window.Created
+}
+
+// IsVisible implements Window interface
+func (window *NullWindow) IsVisible() bool {
+	return window.Visible
+}
+
+// IsFocused implements Window interface
+func (window *NullWindow) IsFocused() bool {
+	return window.Focused
+}
+
+// IsIconified implements Window interface
+func (window *NullWindow) IsIconified() bool {
+	return window.Iconified
+}
+
+// Size implements Window interface
+func (window *NullWindow) Size() Size {
+	return window.State.Size
+}
+
+// Title implements Window interface
+func (window *NullWindow) Title() string {
+	return window.Settings.Title
+}
+
+// Location implements Window interface
+func (window *NullWindow) Location() Location {
+	return window.State.Location
+}
+
+// Resizable implements Window interface
+func (window *NullWindow) Resizable() bool {
+	return window.Settings.Resizable
+}
+
+// Decorated implements Window interface
+func (window *NullWindow) Decorated() bool {
+	return window.Settings.Decorated
+}
+
+// CursorLocked implements Window interface
+func (window *NullWindow) CursorLocked() bool {
+	return window.Settings.CursorLocked
+}
+
+// CursorHidden implements Window interface
+func (window *NullWindow) CursorHidden() bool {
+	return window.Settings.CursorHidden
+}
+
+// Fullscreen implements window interface
+func (window *NullWindow) Fullscreen() bool {
+	return window.FullScreen
+}
+
+// CursorPosition implements window interface
+func (window *NullWindow) CursorPosition() Position {
+	return window.State.Cursor
+}
+
+// StartTextInput implements window interface
+func (window *NullWindow) StartTextInput() {
+	window.State.TextInput = true
+}
+
+// StopTextInput implements window interface
+func (window *NullWindow) StopTextInput() {
+	window.State.TextInput = false
+}
+
+// IsTextInputActive implements window interface
+func (window *NullWindow) IsTextInputActive() bool {
+	return window.State.TextInput
+}
+
+// InjectText acts as if the user typed text, i.e. for tests or bots driving a headless application.
+// Like a real window, nothing is dispatched unless it is created and text input is on
+func (window *NullWindow) InjectText(text string) {
+	if window.Created && window.State.TextInput && text != "" {
+		_, _ = window.Dispatch(TextInputEvent{window.baseEvent, []rune(text)})
+	}
+}
+
+// InjectComposition acts as if an IME is composing text, with its cursor at the given rune. Pass "" to end composition.
+// Like a real window, nothing is dispatched unless it is created and text input is on
+func (window *NullWindow) InjectComposition(text string, cursor int) {
+	if window.Created && window.State.TextInput {
+		_, _ = window.Dispatch(TextCompositionEvent{window.baseEvent, text, cursor})
+	}
+}
+
+// ShouldClose implements window interface
+// Nobody can click the close button of a window that isn't there, so this is always false
+func (window *NullWindow) ShouldClose() bool {
+	return false
+}
+
+// VerifyInitialized returns an error if the window has not been initialized
+func (window *NullWindow) VerifyInitialized() error {
+	if !window.Initialized {
+		return errors.New("window must be initialized")
+	}
+	return nil
+}
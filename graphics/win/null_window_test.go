@@ -0,0 +1,73 @@
+package win_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/graphics/win"
+	"github.com/gjh33/SurrealEngine/graphics/win/wintest"
+)
+
+func TestNullWindowConformance(t *testing.T) {
+	if err := wintest.TestWindow(&win.NullWindow{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNullWindowEventsNeedCreation(t *testing.T) {
+	window := &win.NullWindow{}
+	if err := window.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	var got []event.Event
+	defer event.Observe(window.Bus(), func(e event.Event) { got = append(got, e) }).Cancel()
+
+	_ = window.Hide()
+	_ = window.Show()
+	_ = window.Iconify()
+	_ = window.Restore()
+	_ = window.Resize(win.Size{Width: 640, Height: 480})
+	_ = window.SetLocation(win.Location{X: 10, Y: 20})
+	_ = window.SetFullscreen(true)
+	if len(got) != 0 {
+		t.Errorf("changes before Create dispatched %v", got)
+	}
+	if window.Size() != (win.Size{Width: 640, Height: 480}) || window.Location() != (win.Location{X: 10, Y: 20}) || !window.Fullscreen() {
+		t.Error("changes before Create were not kept for when the window is created")
+	}
+}
+
+func TestNullWindowInjectText(t *testing.T) {
+	window := &win.NullWindow{}
+	if err := window.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	defer event.Subscribe(window.Bus(), func(e win.TextInputEvent) { got = append(got, e.Text()) }).Cancel()
+	defer event.Subscribe(window.Bus(), func(e win.TextCompositionEvent) { got = append(got, "composing "+e.Text) }).Cancel()
+
+	window.StartTextInput()
+	window.InjectText("before")
+	window.InjectComposition("before", 0)
+	if len(got) != 0 {
+		t.Errorf("text injected before Create dispatched %v", got)
+	}
+
+	if err := window.Create(); err != nil {
+		t.Fatal(err)
+	}
+	window.StopTextInput()
+	window.InjectText("off")
+	if len(got) != 0 {
+		t.Errorf("text injected while text input is off dispatched %v", got)
+	}
+
+	window.StartTextInput()
+	window.InjectComposition("hé", 2)
+	window.InjectText("héllo")
+	want := []string{"composing hé", "héllo"}
+	if !slices.Equal(got, want) {
+		t.Errorf("injecting text dispatched %v, want %v", got, want)
+	}
+}
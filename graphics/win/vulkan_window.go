@@ -2,370 +2,502 @@ package win
 
 import (
 	"image"
+	"sync"
+	"time"
 
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/core/mainthread"
 	"github.com/pkg/errors"
 
 	"github.com/vulkan-go/glfw/v3.3/glfw"
 )
 
 // VulkanWindow is the openGL Implementation of a window
+// GLFW only works on the main thread, so calls made from other goroutines are run there and wait for it.
+// Getters can be called from any goroutine, they read the window's state under a lock rather than wait for the main thread
 type VulkanWindow struct {
 	BaseWindow
 	Handle *glfw.Window
 
+	mutex     sync.RWMutex // Guards Settings, State and Handle, which are only written on the main thread
 	baseEvent BaseWindowEvent
+	clicks    clickCounter
 }
 
 // Initialize implements Window interface
 func (window *VulkanWindow) Initialize() error {
-	if err := glfw.Init(); err != nil {
-		return err
-	}
-	if !glfw.VulkanSupported() {
-		return errors.New("vulkan drivers not found")
-	}
+	return mainthread.CallSync(func() error {
+		if err := glfw.Init(); err != nil {
+			return err
+		}
+		if !glfw.VulkanSupported() {
+			return errors.New("vulkan drivers not found")
+		}
 
-	window.baseEvent = BaseWindowEvent{window}
-
-	// Set default settings
-	window.Settings.Title = "Surreal Application"
-	window.Settings.FullScreen = false
-	window.Settings.Resizable = false
-	window.Settings.Decorated = true
-	window.Settings.CursorLocked = false
-	window.Settings.CursorHidden = false
-
-	window.State.Visible = true
-	window.State.Focused = true
-	window.State.Iconified = false
-	window.State.Size = Size{1024, 720}
-
-	// Default to center of screen
-	videoMode := glfw.GetPrimaryMonitor().GetVideoMode()
-	window.State.Location = Location{
-		(videoMode.Width / 2) - (window.State.Size.Width / 2),
-		(videoMode.Height / 2) - (window.State.Size.Height / 2),
-	}
+		window.baseEvent = BaseWindowEvent{window}
+
+		videoMode := glfw.GetPrimaryMonitor().GetVideoMode()
+		window.update(func() {
+			// Set default settings
+			window.Settings.Title = "Surreal Application"
+			window.Settings.FullScreen = false
+			window.Settings.Resizable = false
+			window.Settings.Decorated = true
+			window.Settings.CursorLocked = false
+			window.Settings.CursorHidden = false
+
+			window.State.Visible = true
+			window.State.Focused = true
+			window.State.Iconified = false
+			window.State.Size = Size{1024, 720}
+
+			// Default to center of screen
+			window.State.Location = Location{
+				(videoMode.Width / 2) - (window.State.Size.Width / 2),
+				(videoMode.Height / 2) - (window.State.Size.Height / 2),
+			}
 
-	window.State.Initialized = true
-	_ = window.Dispatch(WindowInitializedEvent{window.baseEvent})
-	return nil
+			window.State.Initialized = true
+		})
+		_, _ = window.Dispatch(WindowInitializedEvent{window.baseEvent})
+		return nil
+	})
 }
 
 // Create implements Window interface
 func (window *VulkanWindow) Create() error {
-	glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI)
-	glfw.WindowHint(glfw.Resizable, boolToGLFW(window.Settings.Resizable))
-	glfw.WindowHint(glfw.Decorated, boolToGLFW(window.Settings.Decorated))
-	glfw.WindowHint(glfw.Focused, boolToGLFW(window.State.Focused)) // I'm open to this being an option in the future
-	glfw.WindowHint(glfw.Visible, boolToGLFW(window.State.Visible))
-	glfw.WindowHint(glfw.AutoIconify, glfw.False) // If you set this to true, you are what's wrong with this world
-	var err error
-	if window.Settings.FullScreen {
-		window.Handle, err = glfw.CreateWindow(window.State.Size.Width, window.State.Size.Height, window.Settings.Title, glfw.GetPrimaryMonitor(), nil)
-	} else {
-		window.Handle, err = glfw.CreateWindow(window.State.Size.Width, window.State.Size.Height, window.Settings.Title, nil, nil)
-	}
-	if err != nil {
-		return err
-	}
-	window.State.Created = true
-	if err := window.setCursorMode(); err != nil {
-		return err
-	}
-
-	// Honor the state the window had before creation
-	if window.State.Iconified {
-		err = window.Handle.Iconify()
+	return mainthread.CallSync(func() error {
+		glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI)
+		glfw.WindowHint(glfw.Resizable, boolToGLFW(window.Settings.Resizable))
+		glfw.WindowHint(glfw.Decorated, boolToGLFW(window.Settings.Decorated))
+		glfw.WindowHint(glfw.Focused, boolToGLFW(window.State.Focused)) // I'm open to this being an option in the future
+		glfw.WindowHint(glfw.Visible, boolToGLFW(window.State.Visible))
+		glfw.WindowHint(glfw.AutoIconify, glfw.False) // If you set this to true, you are what's wrong with this world
+		var monitor *glfw.Monitor
+		if window.Settings.FullScreen {
+			monitor = glfw.GetPrimaryMonitor()
+		}
+		handle, err := glfw.CreateWindow(window.State.Size.Width, window.State.Size.Height, window.Settings.Title, monitor, nil)
 		if err != nil {
 			return err
 		}
-	} else {
-		err = window.Handle.Restore()
-		if err != nil {
+		window.update(func() {
+			window.Handle = handle
+			window.State.Created = true
+		})
+		if err := window.setCursorMode(); err != nil {
 			return err
 		}
-	}
-	window.Handle.SetPos(window.State.Location.X, window.State.Location.Y)
-	window.Handle.SetIcon(window.Icons)
-
-	// Update all values to make sure if anything wasn't created correctly, it's reflected in the model
-	window.State.Visible = glfwToBool(window.Handle.GetAttrib(glfw.Visible))
-	window.State.Focused = glfwToBool(window.Handle.GetAttrib(glfw.Focused))
-	window.State.Iconified = glfwToBool(window.Handle.GetAttrib(glfw.Iconified))
-	width, height := window.Handle.GetSize()
-	window.State.Size = Size{width, height}
-	x, y := window.Handle.GetPos()
-	window.State.Location = Location{x, y}
-
-	// Register events
-	// Sometimes user api calls can fail, or a user changes the state so we need to use these callbacks
-	window.Handle.SetFocusCallback(window.focusChangedCallback)
-	window.Handle.SetPosCallback(window.locationChangedCallback)
-	window.Handle.SetSizeCallback(window.sizeChangedCallback)
-	window.Handle.SetIconifyCallback(window.iconifyChangedCallback)
-
-	_ = window.Dispatch(WindowCreatedEvent{window.baseEvent})
 
-	return nil
+		// Honor the state the window had before creation
+		if window.State.Iconified {
+			err = window.Handle.Iconify()
+			if err != nil {
+				return err
+			}
+		} else {
+			err = window.Handle.Restore()
+			if err != nil {
+				return err
+			}
+		}
+		window.Handle.SetPos(window.State.Location.X, window.State.Location.Y)
+		window.Handle.SetIcon(window.Icons)
+
+		// Update all values to make sure if anything wasn't created correctly, it's reflected in the model
+		width, height := handle.GetSize()
+		x, y := handle.GetPos()
+		cursorX, cursorY := handle.GetCursorPos()
+		window.update(func() {
+			window.State.Visible = glfwToBool(handle.GetAttrib(glfw.Visible))
+			window.State.Focused = glfwToBool(handle.GetAttrib(glfw.Focused))
+			window.State.Iconified = glfwToBool(handle.GetAttrib(glfw.Iconified))
+			window.State.Size = Size{width, height}
+			window.State.Location = Location{x, y}
+			window.State.Cursor = Position{cursorX, cursorY}
+		})
+
+		// Register events
+		// Sometimes user api calls can fail, or a user changes the state so we need to use these callbacks
+		window.Handle.SetFocusCallback(window.focusChangedCallback)
+		window.Handle.SetPosCallback(window.locationChangedCallback)
+		window.Handle.SetSizeCallback(window.sizeChangedCallback)
+		window.Handle.SetIconifyCallback(window.iconifyChangedCallback)
+		window.Handle.SetKeyCallback(window.keyCallback)
+		window.Handle.SetCursorPosCallback(window.cursorMovedCallback)
+		window.Handle.SetCursorEnterCallback(window.cursorEnterCallback)
+		window.Handle.SetMouseButtonCallback(window.mouseButtonCallback)
+		window.Handle.SetScrollCallback(window.scrollCallback)
+		window.Handle.SetCharCallback(window.charCallback)
+
+		_, _ = window.Dispatch(WindowCreatedEvent{window.baseEvent})
+
+		return nil
+	})
 }
 
 // Show implements Window interface
 func (window *VulkanWindow) Show() error {
-	if !window.Visible {
-		if window.Created {
-			window.Handle.Show()
+	return mainthread.CallSync(func() error {
+		if !window.Visible {
+			if window.Created {
+				window.Handle.Show()
+			}
+			window.update(func() { window.State.Visible = true })
+			_, _ = window.Dispatch(WindowShownEvent{window.baseEvent})
 		}
-		window.State.Visible = true
-		_ = window.Dispatch(WindowShownEvent{window.baseEvent})
-	}
-	return nil
+		return nil
+	})
 }
 
 // Hide implements Window interface
 func (window *VulkanWindow) Hide() error {
-	if window.Visible {
-		if window.Created {
-			window.Handle.Hide()
+	return mainthread.CallSync(func() error {
+		if window.Visible {
+			if window.Created {
+				window.Handle.Hide()
+			}
+			window.update(func() { window.State.Visible = false })
+			_, _ = window.Dispatch(WindowHiddenEvent{window.baseEvent})
 		}
-		window.State.Visible = false
-		_ = window.Dispatch(WindowHiddenEvent{window.baseEvent})
-	}
-	return nil
+		return nil
+	})
 }
 
 // Focus implements Window interface
 // Events and state are handled in callback
 func (window *VulkanWindow) Focus() error {
-	if !window.Focused {
-		if window.Created {
-			if err := window.Handle.Focus(); err != nil {
-				return err
+	return mainthread.CallSync(func() error {
+		if !window.Focused {
+			if window.Created {
+				if err := window.Handle.Focus(); err != nil {
+					return err
+				}
+			} else {
+				window.update(func() { window.Focused = true })
 			}
-		} else {
-			window.Focused = true
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // Iconify implements Window interface
 // Events and state are handled in callback
 func (window *VulkanWindow) Iconify() error {
-	if !window.Iconified {
-		if window.Created {
-			if err := window.Handle.Iconify(); err != nil {
-				return err
+	return mainthread.CallSync(func() error {
+		if !window.Iconified {
+			if window.Created {
+				if err := window.Handle.Iconify(); err != nil {
+					return err
+				}
+			} else {
+				window.update(func() { window.Iconified = true })
 			}
-		} else {
-			window.Iconified = true
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // Restore implements Window interface
 // Events and state are handled in callback
 func (window *VulkanWindow) Restore() error {
-	if window.Iconified {
-		if window.Created {
-			if err := window.Handle.Restore(); err != nil {
-				return err
+	return mainthread.CallSync(func() error {
+		if window.Iconified {
+			if window.Created {
+				if err := window.Handle.Restore(); err != nil {
+					return err
+				}
+			} else {
+				window.update(func() { window.Iconified = false })
 			}
-		} else {
-			window.Iconified = false
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // Close implements Window interface
+// Listeners can cancel the WindowCloseRequestedEvent to keep the window open
 func (window *VulkanWindow) Close() error {
-	if window.Created {
-		_ = window.Dispatch(WindowCloseRequestedEvent{window.baseEvent})
-		window.Handle.Destroy()
-		window.Handle = nil
-		window.Created = false
-		_ = window.Dispatch(WindowClosedEvent{window.baseEvent})
-	}
+	return mainthread.CallSync(func() error {
+		if window.Created {
+			request := WindowCloseRequestedEvent{window.baseEvent, &event.Propagation{}}
+			_, _ = window.Dispatch(request)
+			if request.Cancelled() {
+				window.Handle.SetShouldClose(false)
+				return nil
+			}
+			window.Handle.Destroy()
+			window.update(func() {
+				window.Handle = nil
+				window.Created = false
+			})
+			_, _ = window.Dispatch(WindowClosedEvent{window.baseEvent})
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // Resize implements Window interface
 // Events and state implemented in callback
 func (window *VulkanWindow) Resize(size Size) error {
-	if window.Created {
-		window.Handle.SetSize(size.Width, size.Height)
-	} else {
-		window.State.Size = size
-	}
-	return nil
+	return mainthread.CallSync(func() error {
+		if window.Created {
+			window.Handle.SetSize(size.Width, size.Height)
+		} else {
+			window.update(func() { window.State.Size = size })
+		}
+		return nil
+	})
 }
 
 // SetIcons implements Window interface
 func (window *VulkanWindow) SetIcons(icons []image.Image) error {
-	if window.Created {
-		window.Handle.SetIcon(icons)
-	}
-	window.Icons = icons
-	return nil
+	return mainthread.CallSync(func() error {
+		if window.Created {
+			window.Handle.SetIcon(icons)
+		}
+		window.update(func() { window.Icons = icons })
+		return nil
+	})
 }
 
 // SetTitle implements Window interface
 func (window *VulkanWindow) SetTitle(title string) error {
-	if window.Created {
-		window.Handle.SetTitle(title)
-	}
-	window.Settings.Title = title
-	return nil
+	return mainthread.CallSync(func() error {
+		if window.Created {
+			window.Handle.SetTitle(title)
+		}
+		window.update(func() { window.Settings.Title = title })
+		return nil
+	})
 }
 
 // SetLocation implements Window interface
 // Events and state implemented in callback
 func (window *VulkanWindow) SetLocation(location Location) error {
-	if window.Created {
-		window.Handle.SetPos(location.X, location.Y)
-	} else {
-		window.State.Location = location
-	}
-	return nil
+	return mainthread.CallSync(func() error {
+		if window.Created {
+			window.Handle.SetPos(location.X, location.Y)
+		} else {
+			window.update(func() { window.State.Location = location })
+		}
+		return nil
+	})
 }
 
 // SetResizable implements Window interface
 // GLFW 3.3 is required to set attributes post creation!
 func (window *VulkanWindow) SetResizable(resizable bool) error {
-	window.Settings.Resizable = resizable
-	if window.Created {
-		return errors.New("requires glfw 3.3")
-	}
-	return nil
+	return mainthread.CallSync(func() error {
+		window.update(func() { window.Settings.Resizable = resizable })
+		if window.Created {
+			return errors.New("requires glfw 3.3")
+		}
+		return nil
+	})
 }
 
 // SetDecorated implements Window interface
 // GLFW 3.3 is required to set attributes post creation!
 func (window *VulkanWindow) SetDecorated(decorated bool) error {
-	window.Settings.Decorated = decorated
-	if window.Created {
-		return errors.New("requires glfw 3.3")
-	}
-	return nil
+	return mainthread.CallSync(func() error {
+		window.update(func() { window.Settings.Decorated = decorated })
+		if window.Created {
+			return errors.New("requires glfw 3.3")
+		}
+		return nil
+	})
 }
 
 // SetFullscreen implements window interface
 func (window *VulkanWindow) SetFullscreen(fullscreen bool) error {
-	if window.Created {
-		if fullscreen && !window.FullScreen {
-			window.Handle.SetMonitor(glfw.GetPrimaryMonitor(), window.State.Location.X, window.State.Location.Y, window.State.Size.Width, window.State.Size.Height, glfw.DontCare)
-			_ = window.Dispatch(WindowFullscreenEvent{window.baseEvent})
-		}
-		if !fullscreen && window.FullScreen {
-			window.Handle.SetMonitor(nil, window.State.Location.X, window.State.Location.Y, window.State.Size.Width, window.State.Size.Height, glfw.DontCare)
-			_ = window.Dispatch(WindowWindowedEvent{window.baseEvent})
+	return mainthread.CallSync(func() error {
+		if window.Created {
+			if fullscreen && !window.FullScreen {
+				window.Handle.SetMonitor(glfw.GetPrimaryMonitor(), window.State.Location.X, window.State.Location.Y, window.State.Size.Width, window.State.Size.Height, glfw.DontCare)
+				_, _ = window.Dispatch(WindowFullscreenEvent{window.baseEvent})
+			}
+			if !fullscreen && window.FullScreen {
+				window.Handle.SetMonitor(nil, window.State.Location.X, window.State.Location.Y, window.State.Size.Width, window.State.Size.Height, glfw.DontCare)
+				_, _ = window.Dispatch(WindowWindowedEvent{window.baseEvent})
+			}
 		}
-	}
-	window.FullScreen = fullscreen
-	return nil
+		window.update(func() { window.FullScreen = fullscreen })
+		return nil
+	})
+}
+
+// PollEvents implements Window interface
+// GLFW polls every window at once, so this processes events for all of them
+func (window *VulkanWindow) PollEvents() error {
+	return mainthread.CallSync(func() error {
+		glfw.PollEvents()
+		return nil
+	})
 }
 
 // SetCursorLocked implements Window interface
 func (window *VulkanWindow) SetCursorLocked(locked bool) error {
-	window.Settings.CursorLocked = locked
-	if window.Created {
-		if err := window.setCursorMode(); err != nil {
-			return err
+	return mainthread.CallSync(func() error {
+		window.update(func() { window.Settings.CursorLocked = locked })
+		if window.Created {
+			if err := window.setCursorMode(); err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // SetCursorHidden implements Window interface
 func (window *VulkanWindow) SetCursorHidden(hidden bool) error {
-	window.Settings.CursorHidden = hidden
-	if window.Created {
-		if err := window.setCursorMode(); err != nil {
-			return err
+	return mainthread.CallSync(func() error {
+		window.update(func() { window.Settings.CursorHidden = hidden })
+		if window.Created {
+			if err := window.setCursorMode(); err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // IsInitialized implements Window interface
 func (window *VulkanWindow) IsInitialized() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Initialized
 }
 
 // IsCreated implements Window interface
 func (window *VulkanWindow) IsCreated() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Created
 }
 
 // IsVisible implements Window interface
 func (window *VulkanWindow) IsVisible() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Visible
 }
 
 // IsFocused implements Window interface
 func (window *VulkanWindow) IsFocused() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Focused
 }
 
 // IsIconified implements Window interface
 func (window *VulkanWindow) IsIconified() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Iconified
 }
 
 // Size implements Window interface
 func (window *VulkanWindow) Size() Size {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.State.Size
 }
 
 // Title implements Window interface
 func (window *VulkanWindow) Title() string {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Settings.Title
 }
 
 // Location implements Window interface
 func (window *VulkanWindow) Location() Location {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.State.Location
 }
 
 // Resizable implements Window interface
 func (window *VulkanWindow) Resizable() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Settings.Resizable
 }
 
 // Decorated implements Window interface
 func (window *VulkanWindow) Decorated() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Settings.Decorated
 }
 
 // CursorLocked implements Window interface
 func (window *VulkanWindow) CursorLocked() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Settings.CursorLocked
 }
 
 // CursorHidden implements Window interface
 func (window *VulkanWindow) CursorHidden() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.Settings.CursorHidden
 }
 
 // Fullscreen implements window interface
 func (window *VulkanWindow) Fullscreen() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
 	return window.FullScreen
 }
 
+// CursorPosition implements window interface
+func (window *VulkanWindow) CursorPosition() Position {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
+	return window.State.Cursor
+}
+
+// StartTextInput implements window interface.
+// Only committed text is dispatched, as TextInputEvent. GLFW 3.3 has no preedit callback, so there is no TextCompositionEvent
+// and the platform's IME shows what is being composed in its own popup
+func (window *VulkanWindow) StartTextInput() {
+	window.update(func() { window.State.TextInput = true })
+}
+
+// StopTextInput implements window interface
+func (window *VulkanWindow) StopTextInput() {
+	window.update(func() { window.State.TextInput = false })
+}
+
+// IsTextInputActive implements window interface
+func (window *VulkanWindow) IsTextInputActive() bool {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
+	return window.State.TextInput
+}
+
 // ShouldClose implements window interface
+// GLFW allows reading the close flag from any thread, so unlike the setters this doesn't need the main thread
 func (window *VulkanWindow) ShouldClose() bool {
-	if !window.Created {
-		return false
-	}
-	return window.Handle.ShouldClose()
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
+	return window.Created && window.Handle.ShouldClose()
+}
+
+// ActiveSettings implements the Window interface. Returns the window's live settings.
+func (window *VulkanWindow) ActiveSettings() Settings {
+	window.mutex.RLock()
+	defer window.mutex.RUnlock()
+	return window.Settings
 }
 
 // VerifyInitialized returns an error if the window has not been initialized
 func (window *VulkanWindow) VerifyInitialized() error {
-	if !window.Initialized {
+	if !window.IsInitialized() {
 		return errors.New("window must be initialized")
 	}
 	return nil
@@ -373,7 +505,7 @@ func (window *VulkanWindow) VerifyInitialized() error {
 
 // VerifyCreated returns an error if the window has not been created
 func (window *VulkanWindow) VerifyCreated() error {
-	if !window.Created {
+	if !window.IsCreated() {
 		return errors.New("window must be created")
 	}
 	return nil
@@ -385,27 +517,40 @@ func (window *VulkanWindow) setCursorMode() error {
 	}
 	if window.Settings.CursorLocked {
 		window.Handle.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
-		window.Settings.CursorHidden = true // For now i have no choice. I need custom cursor to lock and show
+		window.update(func() { window.Settings.CursorHidden = true }) // For now i have no choice. I need custom cursor to lock and show
 	} else if window.Settings.CursorHidden {
 		window.Handle.SetInputMode(glfw.CursorMode, glfw.CursorHidden)
+	} else {
+		window.Handle.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	}
+	// Raw motion skips the OS pointer acceleration, which is what mouse look wants
+	if glfw.RawMouseMotionSupported() {
+		window.Handle.SetInputMode(glfw.RawMouseMotion, boolToGLFW(window.Settings.CursorLocked))
 	}
 	return nil
 }
 
+// update changes the window's state under its lock. Never dispatch from inside it, listeners read the state back
+func (window *VulkanWindow) update(fn func()) {
+	window.mutex.Lock()
+	defer window.mutex.Unlock()
+	fn()
+}
+
 func (window *VulkanWindow) focusChangedCallback(handle *glfw.Window, focused bool) {
-	window.Focused = focused
+	window.update(func() { window.Focused = focused })
 	if !focused {
-		_ = window.Dispatch(WindowFocusLostEvent{window.baseEvent})
+		_, _ = window.Dispatch(WindowFocusLostEvent{window.baseEvent})
 	} else {
-		_ = window.Dispatch(WindowFocusedEvent{window.baseEvent})
+		_, _ = window.Dispatch(WindowFocusedEvent{window.baseEvent})
 	}
 }
 
 func (window *VulkanWindow) locationChangedCallback(handle *glfw.Window, x int, y int) {
 	newLocation := Location{x, y}
 	oldLocation := window.State.Location
-	window.State.Location = newLocation
-	_ = window.Dispatch(WindowLocationChangedEvent{
+	window.update(func() { window.State.Location = newLocation })
+	_, _ = window.Dispatch(WindowLocationChangedEvent{
 		window.baseEvent,
 		oldLocation,
 		newLocation,
@@ -415,8 +560,8 @@ func (window *VulkanWindow) locationChangedCallback(handle *glfw.Window, x int,
 func (window *VulkanWindow) sizeChangedCallback(handle *glfw.Window, width int, height int) {
 	newSize := Size{width, height}
 	oldSize := window.State.Size
-	window.State.Size = newSize
-	_ = window.Dispatch(WindowResizedEvent{
+	window.update(func() { window.State.Size = newSize })
+	_, _ = window.Dispatch(WindowResizedEvent{
 		window.baseEvent,
 		oldSize,
 		newSize,
@@ -424,11 +569,72 @@ func (window *VulkanWindow) sizeChangedCallback(handle *glfw.Window, width int,
 }
 
 func (window *VulkanWindow) iconifyChangedCallback(handle *glfw.Window, iconified bool) {
-	window.Iconified = iconified
+	window.update(func() { window.Iconified = iconified })
 	if iconified {
-		_ = window.Dispatch(WindowIconifiedEvent{window.baseEvent})
+		_, _ = window.Dispatch(WindowIconifiedEvent{window.baseEvent})
 	} else {
-		_ = window.Dispatch(WindowRestoredEvent{window.baseEvent})
+		_, _ = window.Dispatch(WindowRestoredEvent{window.baseEvent})
+	}
+}
+
+func (window *VulkanWindow) keyCallback(handle *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	engineKey, modifiers := keyFromGLFW(key), modifiersFromGLFW(mods)
+	switch action {
+	case glfw.Press:
+		_, _ = window.Dispatch(KeyPressedEvent{window.baseEvent, engineKey, scancode, modifiers})
+	case glfw.Release:
+		_, _ = window.Dispatch(KeyReleasedEvent{window.baseEvent, engineKey, scancode, modifiers})
+	case glfw.Repeat:
+		_, _ = window.Dispatch(KeyRepeatedEvent{window.baseEvent, engineKey, scancode, modifiers})
+	}
+}
+
+func (window *VulkanWindow) cursorMovedCallback(handle *glfw.Window, x float64, y float64) {
+	newPosition := Position{x, y}
+	oldPosition := window.State.Cursor
+	window.update(func() { window.State.Cursor = newPosition })
+	width, height := handle.GetFramebufferSize()
+	scaleX, scaleY := 1.0, 1.0
+	if window.State.Size.Width > 0 && window.State.Size.Height > 0 {
+		scaleX = float64(width) / float64(window.State.Size.Width)
+		scaleY = float64(height) / float64(window.State.Size.Height)
+	}
+	_, _ = window.Dispatch(CursorMovedEvent{
+		window.baseEvent,
+		newPosition,
+		newPosition.Scale(scaleX, scaleY),
+		newPosition.Sub(oldPosition),
+	})
+}
+
+func (window *VulkanWindow) cursorEnterCallback(handle *glfw.Window, entered bool) {
+	window.update(func() { window.State.Hovered = entered })
+	if entered {
+		_, _ = window.Dispatch(CursorEnteredEvent{window.baseEvent})
+	} else {
+		_, _ = window.Dispatch(CursorLeftEvent{window.baseEvent})
+	}
+}
+
+func (window *VulkanWindow) mouseButtonCallback(handle *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	engineButton, modifiers := MouseButton(button-glfw.MouseButton1), modifiersFromGLFW(mods)
+	switch action {
+	case glfw.Press:
+		clicks := window.clicks.press(engineButton, window.State.Cursor, time.Now())
+		_, _ = window.Dispatch(MouseButtonPressedEvent{window.baseEvent, engineButton, modifiers, window.State.Cursor, clicks})
+	case glfw.Release:
+		_, _ = window.Dispatch(MouseButtonReleasedEvent{window.baseEvent, engineButton, modifiers, window.State.Cursor})
+	}
+}
+
+func (window *VulkanWindow) scrollCallback(handle *glfw.Window, x float64, y float64) {
+	_, _ = window.Dispatch(MouseScrolledEvent{window.baseEvent, Position{x, y}})
+}
+
+func (window *VulkanWindow) charCallback(handle *glfw.Window, char rune) {
+	// Text input is toggled from any goroutine, unlike the rest of the state which only changes on the main thread
+	if window.IsTextInputActive() {
+		_, _ = window.Dispatch(TextInputEvent{window.baseEvent, []rune{char}})
 	}
 }
 
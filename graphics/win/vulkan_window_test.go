@@ -0,0 +1,41 @@
+//go:build display
+
+// Opening a real window needs a display and Vulkan drivers, run these with go test -tags display
+
+package win_test
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gjh33/SurrealEngine/core/mainthread"
+	"github.com/gjh33/SurrealEngine/graphics/win"
+	"github.com/gjh33/SurrealEngine/graphics/win/wintest"
+)
+
+// TestMain keeps the main goroutine draining the main thread queue while the tests run, like the application loop does
+func TestMain(m *testing.M) {
+	unbind := mainthread.Bind()
+	done := make(chan int)
+	go func() { done <- m.Run() }()
+	for {
+		select {
+		case code := <-done:
+			unbind()
+			os.Exit(code)
+		case <-time.After(time.Millisecond):
+			mainthread.Drain()
+		}
+	}
+}
+
+func TestVulkanWindowConformance(t *testing.T) {
+	if runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		t.Skip("no display")
+	}
+	if err := wintest.TestWindow(&win.VulkanWindow{}); err != nil {
+		t.Error(err)
+	}
+}
@@ -20,12 +20,13 @@ type Window interface {
 	Focus() error                        // Force focus on the window
 	Iconify() error                      // Minimizes the window
 	Restore() error                      // Unminimizes the window
-	Close() error                        // Destroys the window
+	Close() error                        // Destroys the window, unless a listener cancels the WindowCloseRequestedEvent
 	Resize(size Size) error              // Resizes the window
 	SetTitle(title string) error         // Sets the window title
 	SetIcons(icons []image.Image) error  // Set window icon to best matched image. To return to default pass nil
 	SetLocation(location Location) error // Sets the position of the upper left corner of the window content
 	SetFullscreen(fullscreen bool) error // Sets the window to fullscreen mode
+	PollEvents() error                   // Processes pending platform events, which fires the window's callbacks
 	// NOTE: Below requires glfw 3.3 and since I'm not interested in forking the go-glfw right now, we'll just disable them
 	// but really these should be relatively unused features anyways. So for now all they do it set initial state for
 	// window creation
@@ -33,24 +34,28 @@ type Window interface {
 	SetDecorated(decorated bool) error // Sets whether or not the window is decorated or just content
 	SetCursorLocked(locked bool) error // Sets whether the cursor is locked to the center of window or not
 	SetCursorHidden(hidden bool) error // Sets whether the cursor is visible over the window
+	StartTextInput()                   // Starts dispatching TextInputEvent, and TextCompositionEvent where supported, i.e. when a text box gains focus
+	StopTextInput()                    // Stops dispatching text events, so gameplay keys don't double as typed characters
 
 	// Information Queries
 	// NOTE: While many of these could be implemented on base window, rather than commit to an implementation
 	// I chose to leave it to the specific implementation of the Window interface
-	IsInitialized() bool // Check if the window has been initialized
-	IsCreated() bool     // Check if the window has been created yet. A Destroyed window is no longer created
-	IsVisible() bool     // Whether a window is being shown or hidden
-	IsFocused() bool     // Whether or not the window is
-	IsIconified() bool   // Whether a window is minimized or not
-	Size() Size          // Window's current size
-	Title() string       // Window's current title
-	Location() Location  // Window's current location
-	Fullscreen() bool    // Whether or not the window is fullscreen or in windowed mode
-	Resizable() bool     // Whether or not the window can be resized
-	Decorated() bool     // Whether or not the window is decorated
-	CursorLocked() bool  // Whether or not the cursor is locked to the center of the window
-	CursorHidden() bool  // Whether or not the cursor is hidden while over
-	ShouldClose() bool   // Whether or not for any reason the window wants to close. I.e. pressing close button
+	IsInitialized() bool      // Check if the window has been initialized
+	IsCreated() bool          // Check if the window has been created yet. A Destroyed window is no longer created
+	IsVisible() bool          // Whether a window is being shown or hidden
+	IsFocused() bool          // Whether or not the window is
+	IsIconified() bool        // Whether a window is minimized or not
+	Size() Size               // Window's current size
+	Title() string            // Window's current title
+	Location() Location       // Window's current location
+	Fullscreen() bool         // Whether or not the window is fullscreen or in windowed mode
+	Resizable() bool          // Whether or not the window can be resized
+	Decorated() bool          // Whether or not the window is decorated
+	CursorLocked() bool       // Whether or not the cursor is locked to the center of the window
+	CursorHidden() bool       // Whether or not the cursor is hidden while over
+	ShouldClose() bool        // Whether or not for any reason the window wants to close. I.e. pressing close button
+	CursorPosition() Position // Where the cursor is over the window content, in window coordinates
+	IsTextInputActive() bool  // Whether or not text events are being dispatched, see StartTextInput
 }
 
 // BaseWindow represents the basis for a window struct in Surreal.
@@ -85,6 +90,9 @@ type State struct {
 	Iconified   bool
 	Size        Size
 	Location    Location
+	Cursor      Position // Where the cursor is, in window coordinates
+	Hovered     bool     // Whether or not the cursor is over the window's content
+	TextInput   bool     // Whether or not text input is on, see Window.StartTextInput
 }
 
 // Size represents the dimensions of a window in pixels
@@ -0,0 +1,251 @@
+package win
+
+//go:generate go run github.com/gjh33/SurrealEngine/cmd/surreal-eventgen -test -dispatcher WindowEventsDispatcher -o window_events_gen.go -doc "WindowEventsDispatcher is a event.Dispatcher that sends out blocking events (processed immediately) for window related state changes.\nUse Bus().Defer to queue them instead, so listeners don't run from inside platform callbacks."
+
+import (
+	"reflect"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+)
+
+// BaseWindowEvent holds the base parameters for a window event
+type BaseWindowEvent struct {
+	Window Window `json:"-"` // Not serialized, see Retarget
+}
+
+// IsWindowEvent returns whether an event is one of the window events, i.e. it embeds BaseWindowEvent
+func IsWindowEvent(e event.Event) bool {
+	_, ok := e.(interface{ isWindowEvent() })
+	return ok
+}
+
+// Retarget returns a copy of a window event that points at another window, i.e. after it was read back from a recording.
+// Events that are not window events are returned unchanged.
+func Retarget(e event.Event, window Window) event.Event {
+	if !IsWindowEvent(e) {
+		return e
+	}
+	value := reflect.New(reflect.TypeOf(e))
+	value.Elem().Set(reflect.ValueOf(e))
+	value.Interface().(interface{ retarget(Window) }).retarget(window)
+	return value.Elem().Interface()
+}
+
+func (e BaseWindowEvent) isWindowEvent() {}
+
+func (e *BaseWindowEvent) retarget(window Window) {
+	e.Window = window
+}
+
+// WindowInitializedEvent is called when the window is initialized
+//
+//surreal:event
+type WindowInitializedEvent struct {
+	BaseWindowEvent
+}
+
+// WindowCreatedEvent is called when the window is created
+//
+//surreal:event
+type WindowCreatedEvent struct {
+	BaseWindowEvent
+}
+
+// WindowShownEvent is called when a window becomes visible to the user
+//
+//surreal:event
+type WindowShownEvent struct {
+	BaseWindowEvent
+}
+
+// WindowHiddenEvent is called when a window becomes hidden from the user
+//
+//surreal:event
+type WindowHiddenEvent struct {
+	BaseWindowEvent
+}
+
+// WindowFocusLostEvent is the event called when a window is unfocused
+//
+//surreal:event
+type WindowFocusLostEvent struct {
+	BaseWindowEvent
+}
+
+// WindowFocusedEvent is the event called when a window regains focus
+//
+//surreal:event
+type WindowFocusedEvent struct {
+	BaseWindowEvent
+}
+
+// WindowIconifiedEvent is the event called when a window is minimized/iconified
+//
+//surreal:event
+type WindowIconifiedEvent struct {
+	BaseWindowEvent
+}
+
+// WindowRestoredEvent is the event called when a window is un iconified
+//
+//surreal:event
+type WindowRestoredEvent struct {
+	BaseWindowEvent
+}
+
+// WindowClosedEvent is the event called after the window has closed
+//
+//surreal:event
+type WindowClosedEvent struct {
+	BaseWindowEvent
+}
+
+// WindowCloseRequestedEvent is the event called right after a close request has been called, before the window closes
+// Cancel it to keep the window open, i.e. to ask the user about unsaved changes
+//
+//surreal:event
+type WindowCloseRequestedEvent struct {
+	BaseWindowEvent
+	*event.Propagation
+}
+
+// WindowResizedEvent is called when the window is resized
+//
+//surreal:event
+type WindowResizedEvent struct {
+	BaseWindowEvent
+	OldSize Size
+	NewSize Size
+}
+
+// WindowLocationChangedEvent is the event called when a window is moved
+//
+//surreal:event method=OnWindowMoved
+type WindowLocationChangedEvent struct {
+	BaseWindowEvent
+	OldLocation Location
+	NewLocation Location
+}
+
+// WindowFullscreenEvent is called when the window enters fullscreen mode
+//
+//surreal:event
+type WindowFullscreenEvent struct {
+	BaseWindowEvent
+}
+
+// WindowWindowedEvent is called when the window is taken out of fullscreen mode
+//
+//surreal:event
+type WindowWindowedEvent struct {
+	BaseWindowEvent
+}
+
+// KeyPressedEvent is the event called when a key is pressed while the window has focus
+//
+//surreal:event
+type KeyPressedEvent struct {
+	BaseWindowEvent
+	Key       Key       // The key, by its position on a US layout
+	Scancode  int       // Platform specific code of the physical key, for keys without a Key code. 0 when unknown
+	Modifiers Modifiers // Modifier keys held at the time
+}
+
+// KeyReleasedEvent is the event called when a key is released while the window has focus
+//
+//surreal:event
+type KeyReleasedEvent struct {
+	BaseWindowEvent
+	Key       Key       // The key, by its position on a US layout
+	Scancode  int       // Platform specific code of the physical key, for keys without a Key code. 0 when unknown
+	Modifiers Modifiers // Modifier keys held at the time
+}
+
+// KeyRepeatedEvent is the event called repeatedly while a key is held down, at the rate set by the OS
+//
+//surreal:event
+type KeyRepeatedEvent struct {
+	BaseWindowEvent
+	Key       Key       // The key, by its position on a US layout
+	Scancode  int       // Platform specific code of the physical key, for keys without a Key code. 0 when unknown
+	Modifiers Modifiers // Modifier keys held at the time
+}
+
+// CursorMovedEvent is the event called when the cursor moves over the window, or anywhere while it is locked
+//
+//surreal:event
+type CursorMovedEvent struct {
+	BaseWindowEvent
+	Position            Position // Where the cursor is, in window coordinates
+	FramebufferPosition Position // Where the cursor is, in framebuffer pixels. Differs from Position on high resolution displays
+	Delta               Position // How far the cursor moved since the last event. Raw, unaccelerated motion while the cursor is locked, when supported
+}
+
+// CursorEnteredEvent is the event called when the cursor moves onto the window's content
+//
+//surreal:event
+type CursorEnteredEvent struct {
+	BaseWindowEvent
+}
+
+// CursorLeftEvent is the event called when the cursor moves off the window's content
+//
+//surreal:event
+type CursorLeftEvent struct {
+	BaseWindowEvent
+}
+
+// MouseButtonPressedEvent is the event called when a mouse button is pressed over the window
+//
+//surreal:event
+type MouseButtonPressedEvent struct {
+	BaseWindowEvent
+	Button    MouseButton
+	Modifiers Modifiers // Modifier keys held at the time
+	Position  Position  // Where the cursor was, in window coordinates
+	Clicks    int       // Presses in a row, i.e. 2 for a double click. See DoubleClickTime and DoubleClickDistance
+}
+
+// MouseButtonReleasedEvent is the event called when a mouse button is released
+//
+//surreal:event
+type MouseButtonReleasedEvent struct {
+	BaseWindowEvent
+	Button    MouseButton
+	Modifiers Modifiers // Modifier keys held at the time
+	Position  Position  // Where the cursor was, in window coordinates
+}
+
+// MouseScrolledEvent is the event called when the mouse wheel turns or the trackpad scrolls over the window
+//
+//surreal:event
+type MouseScrolledEvent struct {
+	BaseWindowEvent
+	Offset Position // How far it scrolled. Y is vertical, positive away from the user. Trackpads report fractions and X
+}
+
+// TextInputEvent is the event called when the user types text while text input is on, see Window.StartTextInput.
+// Unlike key events it follows the keyboard layout, dead keys and IME, so it is what chat boxes and name entry should read
+//
+//surreal:event
+type TextInputEvent struct {
+	BaseWindowEvent
+	Runes []rune // The characters typed, usually one
+}
+
+// Text returns the typed characters as a string
+func (e TextInputEvent) Text() string {
+	return string(e.Runes)
+}
+
+// TextCompositionEvent is the event called while an IME composes text, i.e. typing Japanese phonetically before picking kanji.
+// The composition is shown in place but not yet typed; once the user commits it, it arrives as a TextInputEvent.
+// GLFW 3.3 does not report composition, so VulkanWindow never dispatches it and leaves showing the composition to the
+// platform's own IME popup. NullWindow and FakeWindow dispatch it with InjectComposition, for testing text boxes that draw it
+//
+//surreal:event
+type TextCompositionEvent struct {
+	BaseWindowEvent
+	Text   string // The text being composed. Empty once composition ends, whether it was committed or cancelled
+	Cursor int    // Where the IME's cursor is in Text, in runes
+}
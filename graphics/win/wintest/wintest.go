@@ -0,0 +1,276 @@
+// Package wintest checks that win.Window implementations behave alike, so code tested against a win.FakeWindow
+// behaves the same on a real one.
+package wintest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+	"github.com/gjh33/SurrealEngine/graphics/win"
+)
+
+// TestWindow runs the conformance checks against a new, uninitialized window, i.e.
+//
+//	if err := wintest.TestWindow(&win.FakeWindow{}); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// The window must expose its event bus with a Bus method, as the windows in package win do.
+// Windows that show on screen need a display, and have to be tested on the main thread.
+// It returns every check that failed, joined into one error.
+func TestWindow(window win.Window) error {
+	source, ok := window.(interface{ Bus() *event.Bus })
+	if !ok {
+		return fmt.Errorf("%T does not expose its event bus", window)
+	}
+	checker := &checker{window: window}
+	subscription := event.Observe(source.Bus(), func(e event.Event) { checker.events = append(checker.events, e) })
+	defer subscription.Cancel()
+
+	checker.lifecycle()
+	if !window.IsCreated() {
+		return errors.Join(checker.failures...)
+	}
+	checker.visibility()
+	checker.focus()
+	checker.iconify()
+	checker.resize()
+	checker.location()
+	checker.title()
+	checker.fullscreen()
+	checker.cursor()
+	checker.close(source.Bus())
+	return errors.Join(checker.failures...)
+}
+
+// checker runs the checks, recording events and failures
+type checker struct {
+	window   win.Window
+	events   []event.Event
+	failures []error
+}
+
+// failf records a failed check
+func (checker *checker) failf(format string, args ...interface{}) {
+	checker.failures = append(checker.failures, fmt.Errorf(format, args...))
+}
+
+// expect checks that an event of the same type as want was dispatched since the last call, by and for the window
+func (checker *checker) expect(action string, want event.Event) event.Event {
+	if err := checker.window.PollEvents(); err != nil {
+		checker.failf("%s: PollEvents: %s", action, err.Error())
+	}
+	defer func() { checker.events = nil }()
+	for _, e := range checker.events {
+		if reflect.TypeOf(e) != reflect.TypeOf(want) {
+			continue
+		}
+		if reflect.ValueOf(e).FieldByName("Window").Interface() != checker.window {
+			checker.failf("%s: %T does not carry the window that sent it", action, e)
+		}
+		return e
+	}
+	checker.failf("%s: expected %T", action, want)
+	return nil
+}
+
+// lifecycle checks initializing and creating the window
+func (checker *checker) lifecycle() {
+	window := checker.window
+	if err := window.Initialize(); err != nil {
+		checker.failf("Initialize: %s", err.Error())
+		return
+	}
+	checker.expect("Initialize", win.WindowInitializedEvent{})
+	if !window.IsInitialized() {
+		checker.failf("Initialize: IsInitialized is false")
+	}
+	if window.IsCreated() {
+		checker.failf("Initialize: IsCreated is true before Create")
+	}
+	if err := window.Create(); err != nil {
+		checker.failf("Create: %s", err.Error())
+		return
+	}
+	checker.expect("Create", win.WindowCreatedEvent{})
+	if !window.IsCreated() {
+		checker.failf("Create: IsCreated is false")
+	}
+}
+
+// visibility checks hiding and showing the window
+func (checker *checker) visibility() {
+	window := checker.window
+	if err := window.Hide(); err != nil {
+		checker.failf("Hide: %s", err.Error())
+	}
+	checker.expect("Hide", win.WindowHiddenEvent{})
+	if window.IsVisible() {
+		checker.failf("Hide: IsVisible is true")
+	}
+	if err := window.Show(); err != nil {
+		checker.failf("Show: %s", err.Error())
+	}
+	checker.expect("Show", win.WindowShownEvent{})
+	if !window.IsVisible() {
+		checker.failf("Show: IsVisible is false")
+	}
+}
+
+// focus checks focusing the window. Nothing can take focus away from it portably, so it is only sent when unfocused
+func (checker *checker) focus() {
+	window := checker.window
+	focused := window.IsFocused()
+	if err := window.Focus(); err != nil {
+		checker.failf("Focus: %s", err.Error())
+	}
+	if focused {
+		if err := window.PollEvents(); err != nil {
+			checker.failf("Focus: PollEvents: %s", err.Error())
+		}
+		checker.events = nil
+	} else {
+		checker.expect("Focus", win.WindowFocusedEvent{})
+	}
+	if !window.IsFocused() {
+		checker.failf("Focus: IsFocused is false")
+	}
+}
+
+// iconify checks minimizing the window and bringing it back
+func (checker *checker) iconify() {
+	window := checker.window
+	if err := window.Iconify(); err != nil {
+		checker.failf("Iconify: %s", err.Error())
+	}
+	checker.expect("Iconify", win.WindowIconifiedEvent{})
+	if !window.IsIconified() {
+		checker.failf("Iconify: IsIconified is false")
+	}
+	if err := window.Restore(); err != nil {
+		checker.failf("Restore: %s", err.Error())
+	}
+	checker.expect("Restore", win.WindowRestoredEvent{})
+	if window.IsIconified() {
+		checker.failf("Restore: IsIconified is true")
+	}
+}
+
+// resize checks resizing the window
+func (checker *checker) resize() {
+	window := checker.window
+	size := win.Size{Width: window.Size().Width / 2, Height: window.Size().Height / 2}
+	if err := window.Resize(size); err != nil {
+		checker.failf("Resize: %s", err.Error())
+	}
+	if e, ok := checker.expect("Resize", win.WindowResizedEvent{}).(win.WindowResizedEvent); ok && e.NewSize != size {
+		checker.failf("Resize: WindowResizedEvent.NewSize is %v, expected %v", e.NewSize, size)
+	}
+	if window.Size() != size {
+		checker.failf("Resize: Size is %v, expected %v", window.Size(), size)
+	}
+}
+
+// location checks moving the window
+func (checker *checker) location() {
+	window := checker.window
+	location := win.Location{X: window.Location().X + 10, Y: window.Location().Y + 10}
+	if err := window.SetLocation(location); err != nil {
+		checker.failf("SetLocation: %s", err.Error())
+	}
+	if e, ok := checker.expect("SetLocation", win.WindowLocationChangedEvent{}).(win.WindowLocationChangedEvent); ok && e.NewLocation != location {
+		checker.failf("SetLocation: WindowLocationChangedEvent.NewLocation is %v, expected %v", e.NewLocation, location)
+	}
+	if window.Location() != location {
+		checker.failf("SetLocation: Location is %v, expected %v", window.Location(), location)
+	}
+}
+
+// title checks retitling the window
+func (checker *checker) title() {
+	window := checker.window
+	if err := window.SetTitle("wintest"); err != nil {
+		checker.failf("SetTitle: %s", err.Error())
+	}
+	if window.Title() != "wintest" {
+		checker.failf("SetTitle: Title is %q", window.Title())
+	}
+	checker.events = nil
+}
+
+// fullscreen checks going fullscreen and back
+func (checker *checker) fullscreen() {
+	window := checker.window
+	if err := window.SetFullscreen(true); err != nil {
+		checker.failf("SetFullscreen: %s", err.Error())
+	}
+	checker.expect("SetFullscreen(true)", win.WindowFullscreenEvent{})
+	if !window.Fullscreen() {
+		checker.failf("SetFullscreen(true): Fullscreen is false")
+	}
+	if err := window.SetFullscreen(false); err != nil {
+		checker.failf("SetFullscreen: %s", err.Error())
+	}
+	checker.expect("SetFullscreen(false)", win.WindowWindowedEvent{})
+	if window.Fullscreen() {
+		checker.failf("SetFullscreen(false): Fullscreen is true")
+	}
+}
+
+// cursor checks hiding and locking the cursor. Locking may hide it too, so hiding is checked first
+func (checker *checker) cursor() {
+	window := checker.window
+	if err := window.SetCursorHidden(true); err != nil {
+		checker.failf("SetCursorHidden: %s", err.Error())
+	}
+	if !window.CursorHidden() {
+		checker.failf("SetCursorHidden(true): CursorHidden is false")
+	}
+	if err := window.SetCursorHidden(false); err != nil {
+		checker.failf("SetCursorHidden: %s", err.Error())
+	}
+	if window.CursorHidden() {
+		checker.failf("SetCursorHidden(false): CursorHidden is true")
+	}
+	if err := window.SetCursorLocked(true); err != nil {
+		checker.failf("SetCursorLocked: %s", err.Error())
+	}
+	if !window.CursorLocked() {
+		checker.failf("SetCursorLocked(true): CursorLocked is false")
+	}
+	if err := window.SetCursorLocked(false); err != nil {
+		checker.failf("SetCursorLocked: %s", err.Error())
+	}
+	if window.CursorLocked() {
+		checker.failf("SetCursorLocked(false): CursorLocked is true")
+	}
+	_ = window.SetCursorHidden(false)
+	checker.events = nil
+}
+
+// close checks that closing can be cancelled, then closes the window
+func (checker *checker) close(bus *event.Bus) {
+	window := checker.window
+	veto := event.Subscribe(bus, func(e win.WindowCloseRequestedEvent) { e.Cancel() })
+	if err := window.Close(); err != nil {
+		checker.failf("Close: %s", err.Error())
+	}
+	veto.Cancel()
+	checker.expect("Close (cancelled)", win.WindowCloseRequestedEvent{})
+	if !window.IsCreated() {
+		checker.failf("Close: the window closed even though the request was cancelled")
+	}
+	if window.ShouldClose() {
+		checker.failf("Close: ShouldClose is true after the request was cancelled")
+	}
+
+	if err := window.Close(); err != nil {
+		checker.failf("Close: %s", err.Error())
+	}
+	checker.expect("Close", win.WindowClosedEvent{})
+	if window.IsCreated() {
+		checker.failf("Close: IsCreated is true")
+	}
+}
@@ -0,0 +1,44 @@
+package gamepad
+
+// MaxJoysticks is the number of joystick slots, IDs run from 0 to MaxJoysticks-1
+const MaxJoysticks = 16
+
+// Backend reads joysticks from the platform. See GLFWBackend, and Virtual for tests
+type Backend interface {
+	// Poll reads the device connected to a joystick slot, returning false if the slot is empty.
+	// An error means the slot could not be read at all, which Gamepads takes as no change rather than an unplug
+	Poll(id int) (Device, bool, error)
+}
+
+// Device is what a backend read from a joystick slot
+type Device struct {
+	Name     string
+	GUID     string // SDL style GUID, 32 hex digits
+	Raw      Raw
+	State    State // Standard layout state, only set if Standard is
+	Standard bool  // If the backend knows the device's layout itself. Otherwise it is looked up in the Mappings
+}
+
+// Raw is the unmapped input of a joystick, in the order the device reports it
+type Raw struct {
+	Axes    []float64 // From -1 to 1
+	Buttons []bool
+	Hats    []Hat
+}
+
+// Hat is the direction a joystick hat, usually a d-pad, is pushed in
+type Hat int
+
+// Declaring Hat enum values, they are flags so diagonals combine two
+const (
+	HatCentered Hat = 0
+	HatUp       Hat = 1 << (iota - 1)
+	HatRight
+	HatDown
+	HatLeft
+)
+
+// Has returns if the hat is pushed in a direction, i.e. true for HatUp when it is up and right
+func (hat Hat) Has(direction Hat) bool {
+	return hat&direction != 0
+}
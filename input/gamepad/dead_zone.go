@@ -0,0 +1,40 @@
+package gamepad
+
+import "math"
+
+// DeadZone is how much of the analog inputs' range is ignored, as worn sticks and triggers rarely rest exactly at zero.
+// The remaining range is stretched back out, so input still starts at 0 and reaches 1
+type DeadZone struct {
+	Stick   float64 // Radius around the center of a stick, from 0 to 1. It is radial so diagonals are not snapped to the axes
+	Trigger float64 // Travel at the start of a trigger, from 0 to 1
+}
+
+// DefaultDeadZone is the dead zone gamepads start with, generous enough for most worn controllers
+var DefaultDeadZone = DeadZone{Stick: 0.15, Trigger: 0.05}
+
+// Apply returns the state with the dead zones applied to its axes
+func (zone DeadZone) Apply(state State) State {
+	state.Axes[AxisLeftX], state.Axes[AxisLeftY] = radialDeadZone(state.Axes[AxisLeftX], state.Axes[AxisLeftY], zone.Stick)
+	state.Axes[AxisRightX], state.Axes[AxisRightY] = radialDeadZone(state.Axes[AxisRightX], state.Axes[AxisRightY], zone.Stick)
+	state.Axes[AxisLeftTrigger] = linearDeadZone(state.Axes[AxisLeftTrigger], zone.Trigger)
+	state.Axes[AxisRightTrigger] = linearDeadZone(state.Axes[AxisRightTrigger], zone.Trigger)
+	return state
+}
+
+// radialDeadZone zeroes a stick within radius of its center, and rescales it outside, keeping its direction
+func radialDeadZone(x, y, radius float64) (float64, float64) {
+	length := math.Hypot(x, y)
+	if length <= radius || length == 0 {
+		return 0, 0
+	}
+	scaled := math.Min((length-radius)/(1-radius), 1)
+	return x / length * scaled, y / length * scaled
+}
+
+// linearDeadZone zeroes a trigger below the threshold, and rescales it above
+func linearDeadZone(value, threshold float64) float64 {
+	if value <= threshold {
+		return 0
+	}
+	return math.Min((value-threshold)/(1-threshold), 1)
+}
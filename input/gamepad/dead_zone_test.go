@@ -0,0 +1,58 @@
+package gamepad
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDeadZoneApply(t *testing.T) {
+	zone := DeadZone{Stick: 0.2, Trigger: 0.1}
+	for _, test := range []struct {
+		name       string
+		axis, pair Axis
+		in, want   [2]float64
+	}{
+		{"stick inside", AxisLeftX, AxisLeftY, [2]float64{0.1, 0.1}, [2]float64{0, 0}},
+		{"stick rescaled", AxisLeftX, AxisLeftY, [2]float64{0.6, 0}, [2]float64{0.5, 0}},
+		{"stick keeps direction", AxisRightX, AxisRightY, [2]float64{0.6, 0.8}, [2]float64{0.6, 0.8}},
+		{"stick is radial", AxisRightX, AxisRightY, [2]float64{0.3, 0.4}, [2]float64{0.225, 0.3}},
+		{"triggers", AxisLeftTrigger, AxisRightTrigger, [2]float64{0.05, 0.55}, [2]float64{0, 0.5}},
+	} {
+		var state State
+		state.Axes[test.axis], state.Axes[test.pair] = test.in[0], test.in[1]
+		got := zone.Apply(state)
+		if !near(got.Axes[test.axis], test.want[0]) || !near(got.Axes[test.pair], test.want[1]) {
+			t.Errorf("%s: %v, %v became %v, %v, want %v", test.name, test.axis, test.pair, got.Axes[test.axis], got.Axes[test.pair], test.want)
+		}
+	}
+}
+
+func TestDeadZoneOnlyAppliesToTheStandardLayout(t *testing.T) {
+	virtual := new(Virtual)
+	gamepads := New(virtual)
+	gamepads.Mappings.Add(MustParseMapping("03000000de280000ff11000001000000,Mapped,leftx:a0,"))
+	pad := virtual.Connect("pad")
+	stick := virtual.ConnectJoystick("stick", "03000000de280000ff11000001000000", 1, 0, 0)
+	virtual.SetAxis(pad, AxisLeftX, 0.1)
+	virtual.SetAxis(pad, AxisRightTrigger, 1)
+	virtual.SetRawAxis(stick, 0, 0.1)
+	gamepads.Update()
+
+	if x := gamepads.Get(pad).Axis(AxisLeftX); x != 0 {
+		t.Errorf("a stick resting in the default dead zone reads %v", x)
+	}
+	if trigger := gamepads.Get(pad).Axis(AxisRightTrigger); trigger != 1 {
+		t.Errorf("a trigger pulled all the way reads %v", trigger)
+	}
+	if x := gamepads.Get(stick).Axis(AxisLeftX); x != 0 {
+		t.Errorf("a mapped stick resting in the default dead zone reads %v", x)
+	}
+	if raw := gamepads.Get(stick).Raw().Axes[0]; raw != 0.1 {
+		t.Errorf("the raw axis reads %v, want it untouched", raw)
+	}
+}
+
+// near returns if two axis values are equal, give or take rounding
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
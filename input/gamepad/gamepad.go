@@ -0,0 +1,239 @@
+// Package gamepad reads game controllers and joysticks, mapped onto the standard gamepad layout:
+// an Xbox style controller with two sticks, two analog triggers, a d-pad and fifteen buttons.
+package gamepad
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Button is a button of the standard gamepad layout, named after their position on an Xbox controller
+type Button int
+
+// Declaring Button enum values
+const (
+	ButtonA Button = iota // Bottom face button
+	ButtonB               // Right face button
+	ButtonX               // Left face button
+	ButtonY               // Top face button
+	ButtonLeftBumper
+	ButtonRightBumper
+	ButtonBack
+	ButtonStart
+	ButtonGuide
+	ButtonLeftThumb // Pressing down the left stick
+	ButtonRightThumb
+	ButtonDpadUp
+	ButtonDpadRight
+	ButtonDpadDown
+	ButtonDpadLeft
+
+	// ButtonCount is the number of gamepad buttons, for sizing tables indexed by Button
+	ButtonCount
+)
+
+// String implements the fmt.Stringer interface
+func (button Button) String() string {
+	switch button {
+	case ButtonA:
+		return "A"
+	case ButtonB:
+		return "B"
+	case ButtonX:
+		return "X"
+	case ButtonY:
+		return "Y"
+	case ButtonLeftBumper:
+		return "LeftBumper"
+	case ButtonRightBumper:
+		return "RightBumper"
+	case ButtonBack:
+		return "Back"
+	case ButtonStart:
+		return "Start"
+	case ButtonGuide:
+		return "Guide"
+	case ButtonLeftThumb:
+		return "LeftThumb"
+	case ButtonRightThumb:
+		return "RightThumb"
+	case ButtonDpadUp:
+		return "DpadUp"
+	case ButtonDpadRight:
+		return "DpadRight"
+	case ButtonDpadDown:
+		return "DpadDown"
+	case ButtonDpadLeft:
+		return "DpadLeft"
+	}
+	return fmt.Sprintf("Button(%d)", int(button))
+}
+
+// Axis is an analog input of the standard gamepad layout.
+// Sticks range from -1 to 1, with Y growing downwards like window coordinates. Triggers range from 0 when let go to 1 when fully pressed
+type Axis int
+
+// Declaring Axis enum values
+const (
+	AxisLeftX Axis = iota
+	AxisLeftY
+	AxisRightX
+	AxisRightY
+	AxisLeftTrigger
+	AxisRightTrigger
+
+	// AxisCount is the number of gamepad axes, for sizing tables indexed by Axis
+	AxisCount
+)
+
+// String implements the fmt.Stringer interface
+func (axis Axis) String() string {
+	switch axis {
+	case AxisLeftX:
+		return "LeftX"
+	case AxisLeftY:
+		return "LeftY"
+	case AxisRightX:
+		return "RightX"
+	case AxisRightY:
+		return "RightY"
+	case AxisLeftTrigger:
+		return "LeftTrigger"
+	case AxisRightTrigger:
+		return "RightTrigger"
+	}
+	return fmt.Sprintf("Axis(%d)", int(axis))
+}
+
+// IsTrigger returns if the axis is one of the triggers, which only go one way
+func (axis Axis) IsTrigger() bool {
+	return axis == AxisLeftTrigger || axis == AxisRightTrigger
+}
+
+// State is a snapshot of a gamepad in the standard layout
+type State struct {
+	Buttons [ButtonCount]bool
+	Axes    [AxisCount]float64
+}
+
+// Gamepad is a connected controller or joystick. Its state is polled by Gamepads.Update,
+// so it is stable for the whole frame, and safe to read from any goroutine.
+// Presses shorter than a frame are missed, as joysticks are only read once a frame
+type Gamepad struct {
+	ID   int    // Joystick slot the device is connected to, from 0 to MaxJoysticks-1
+	Name string // Name of its mapping if it has one, otherwise the one the device reports
+	GUID string // SDL style device GUID, which mappings are looked up by
+
+	mutex     sync.RWMutex
+	connected bool
+	mapped    bool
+	state     State
+	previous  State
+	raw       Raw
+}
+
+// IsConnected returns if the device is still plugged in. Disconnected gamepads have everything let go of
+func (gamepad *Gamepad) IsConnected() bool {
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.connected
+}
+
+// IsMapped returns if the device has a standard layout, known to the backend or from a mapping.
+// Unmapped joysticks only have Raw input
+func (gamepad *Gamepad) IsMapped() bool {
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.mapped
+}
+
+// IsDown returns if the button is held down
+func (gamepad *Gamepad) IsDown(button Button) bool {
+	if button < 0 || button >= ButtonCount {
+		return false
+	}
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.state.Buttons[button]
+}
+
+// WasPressedThisFrame returns if the button went down this frame
+func (gamepad *Gamepad) WasPressedThisFrame(button Button) bool {
+	if button < 0 || button >= ButtonCount {
+		return false
+	}
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.state.Buttons[button] && !gamepad.previous.Buttons[button]
+}
+
+// WasReleasedThisFrame returns if the button went up this frame
+func (gamepad *Gamepad) WasReleasedThisFrame(button Button) bool {
+	if button < 0 || button >= ButtonCount {
+		return false
+	}
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return !gamepad.state.Buttons[button] && gamepad.previous.Buttons[button]
+}
+
+// Axis returns the position of an axis, after dead zones
+func (gamepad *Gamepad) Axis(axis Axis) float64 {
+	if axis < 0 || axis >= AxisCount {
+		return 0
+	}
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.state.Axes[axis]
+}
+
+// LeftStick returns the position of the left stick, after dead zones
+func (gamepad *Gamepad) LeftStick() (x, y float64) {
+	return gamepad.Axis(AxisLeftX), gamepad.Axis(AxisLeftY)
+}
+
+// RightStick returns the position of the right stick, after dead zones
+func (gamepad *Gamepad) RightStick() (x, y float64) {
+	return gamepad.Axis(AxisRightX), gamepad.Axis(AxisRightY)
+}
+
+// State returns the whole standard layout state, after dead zones
+func (gamepad *Gamepad) State() State {
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.state
+}
+
+// Raw returns the unmapped input of the device, for joysticks without a standard layout
+func (gamepad *Gamepad) Raw() Raw {
+	gamepad.mutex.RLock()
+	defer gamepad.mutex.RUnlock()
+	return gamepad.raw
+}
+
+// update starts a new frame with the device's latest state
+func (gamepad *Gamepad) update(state State, raw Raw, mapped bool) {
+	gamepad.mutex.Lock()
+	defer gamepad.mutex.Unlock()
+	gamepad.previous = gamepad.state
+	gamepad.state = state
+	gamepad.raw = raw
+	gamepad.mapped = mapped
+}
+
+// hold starts a new frame with nothing changed, for when the device could not be read
+func (gamepad *Gamepad) hold() {
+	gamepad.mutex.Lock()
+	defer gamepad.mutex.Unlock()
+	gamepad.previous = gamepad.state
+}
+
+// disconnect lets go of everything, so the last frame reports the buttons held as released
+func (gamepad *Gamepad) disconnect() {
+	gamepad.mutex.Lock()
+	defer gamepad.mutex.Unlock()
+	gamepad.previous = gamepad.state
+	gamepad.state = State{}
+	gamepad.raw = Raw{}
+	gamepad.connected = false
+}
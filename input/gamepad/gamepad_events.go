@@ -0,0 +1,21 @@
+package gamepad
+
+//go:generate go run github.com/gjh33/SurrealEngine/cmd/surreal-eventgen -test -dispatcher GamepadEventsDispatcher -o gamepad_events_gen.go -doc "GamepadEventsDispatcher is a event.Dispatcher that sends out blocking events (processed immediately) when gamepads are plugged in or out.\nThey are sent from Gamepads.Update, so listeners run on the main thread."
+
+// GamepadConnectedEvent is called when a gamepad or joystick is plugged in, including the ones already plugged in on the first update
+//
+//surreal:event
+type GamepadConnectedEvent struct {
+	ID      int
+	Name    string
+	Gamepad *Gamepad `json:"-"` // Not serialized, look it up by ID with Gamepads.Get
+}
+
+// GamepadDisconnectedEvent is called when a gamepad or joystick is unplugged
+//
+//surreal:event
+type GamepadDisconnectedEvent struct {
+	ID      int
+	Name    string
+	Gamepad *Gamepad `json:"-"` // Not serialized, and no longer connected
+}
@@ -0,0 +1,52 @@
+// Code generated by surreal-eventgen. DO NOT EDIT.
+
+package gamepad
+
+import "github.com/gjh33/SurrealEngine/core/event"
+
+// GamepadEventsDispatcher is a event.Dispatcher that sends out blocking events (processed immediately) when gamepads are plugged in or out.
+// They are sent from Gamepads.Update, so listeners run on the main thread.
+type GamepadEventsDispatcher struct {
+	bus event.Bus
+}
+
+// gamepadBindings adapts the listener interfaces onto the bus
+var gamepadBindings = event.Bindings{
+	event.Listener(GamepadConnectedListener.OnGamepadConnected),
+	event.Listener(GamepadDisconnectedListener.OnGamepadDisconnected),
+}
+
+// RegisterEvents adds every event of the dispatcher to a registry, so they can be recorded, replayed and bridged
+func RegisterEvents(registry *event.Registry) {
+	event.Register[GamepadConnectedEvent](registry, "gamepad.GamepadConnectedEvent")
+	event.Register[GamepadDisconnectedEvent](registry, "gamepad.GamepadDisconnectedEvent")
+}
+
+// Bus returns the underlying event bus, for use with event.Subscribe and event.Publish
+func (dispatcher *GamepadEventsDispatcher) Bus() *event.Bus {
+	return &dispatcher.bus
+}
+
+// Subscribe implements the event.Dispatcher interface
+func (dispatcher *GamepadEventsDispatcher) Subscribe(subscriber event.Subscriber) (*event.Subscription, error) {
+	return gamepadBindings.Bind(&dispatcher.bus, subscriber)
+}
+
+// Dispatch implements the event.Dispatcher interface
+func (dispatcher *GamepadEventsDispatcher) Dispatch(e event.Event) (bool, error) {
+	switch e.(type) {
+	case GamepadConnectedEvent, GamepadDisconnectedEvent:
+		return dispatcher.bus.Dispatch(e)
+	}
+	return false, &event.UnknownEventError{}
+}
+
+// GamepadConnectedListener defines the subscriber interface for GamepadConnectedEvent
+type GamepadConnectedListener interface {
+	OnGamepadConnected(e GamepadConnectedEvent)
+}
+
+// GamepadDisconnectedListener defines the subscriber interface for GamepadDisconnectedEvent
+type GamepadDisconnectedListener interface {
+	OnGamepadDisconnected(e GamepadDisconnectedEvent)
+}
@@ -0,0 +1,119 @@
+package gamepad
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gjh33/SurrealEngine/core/event"
+)
+
+// Gamepads keeps track of the gamepads and joysticks of a backend, for polling instead of listening for events.
+// Call Update once a frame, the gamepad Module does so before ApplicationUpdateEvent listeners run
+type Gamepads struct {
+	GamepadEventsDispatcher
+	Backend  Backend  // Where devices are read from. Nil has no devices, i.e. when headless
+	DeadZone DeadZone // Applied to the standard layout, not to raw input
+	Mappings Mappings // Layouts for devices the backend does not know, or knows wrong. They take precedence over the backend's
+
+	mutex     sync.RWMutex
+	gamepads  [MaxJoysticks]*Gamepad
+	unplugged []*Gamepad // Disconnected last Update, still to let go of their buttons for good
+}
+
+// New is the default constructor for Gamepads
+func New(backend Backend) (obj *Gamepads) {
+	obj = new(Gamepads)
+	obj.Backend = backend
+	obj.DeadZone = DefaultDeadZone
+	return
+}
+
+// Get returns the device connected to a joystick slot, or nil if there is none
+func (gamepads *Gamepads) Get(id int) *Gamepad {
+	if id < 0 || id >= MaxJoysticks {
+		return nil
+	}
+	gamepads.mutex.RLock()
+	defer gamepads.mutex.RUnlock()
+	return gamepads.gamepads[id]
+}
+
+// Connected returns the connected devices, ordered by ID
+func (gamepads *Gamepads) Connected() []*Gamepad {
+	gamepads.mutex.RLock()
+	defer gamepads.mutex.RUnlock()
+	var connected []*Gamepad
+	for _, gamepad := range gamepads.gamepads {
+		if gamepad != nil {
+			connected = append(connected, gamepad)
+		}
+	}
+	return connected
+}
+
+// Update polls every joystick slot, starting a new frame.
+// GamepadConnectedEvent and GamepadDisconnectedEvent are dispatched once every device is up to date.
+// Slots the backend failed to read keep their last state, and the failures are returned joined into one error
+func (gamepads *Gamepads) Update() error {
+	var events []event.Event
+	var errs []error
+	gamepads.mutex.Lock()
+	for _, gamepad := range gamepads.unplugged {
+		gamepad.update(State{}, Raw{}, false)
+	}
+	gamepads.unplugged = gamepads.unplugged[:0]
+	for id := range gamepads.gamepads {
+		device, ok, err := gamepads.poll(id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to poll joystick %d.\n Poll Error: %s", id, err.Error()))
+			if gamepad := gamepads.gamepads[id]; gamepad != nil {
+				gamepad.hold()
+			}
+			continue
+		}
+		gamepad := gamepads.gamepads[id]
+		if gamepad != nil && (!ok || device.GUID != gamepad.GUID) {
+			// Unplugging one device and plugging in another between two updates can land both in the same slot
+			gamepad.disconnect()
+			gamepads.gamepads[id] = nil
+			gamepads.unplugged = append(gamepads.unplugged, gamepad)
+			events = append(events, GamepadDisconnectedEvent{ID: id, Name: gamepad.Name, Gamepad: gamepad})
+			gamepad = nil
+		}
+		if !ok {
+			continue
+		}
+		mapping, hasMapping := gamepads.Mappings.Lookup(device.GUID)
+		if gamepad == nil {
+			gamepad = &Gamepad{ID: id, Name: device.Name, GUID: device.GUID, connected: true}
+			if hasMapping && mapping.Name != "" {
+				gamepad.Name = mapping.Name
+			}
+			gamepads.gamepads[id] = gamepad
+			events = append(events, GamepadConnectedEvent{ID: id, Name: gamepad.Name, Gamepad: gamepad})
+		}
+		state, mapped := device.State, device.Standard
+		if hasMapping {
+			state, mapped = mapping.Apply(device.Raw), true
+		}
+		if mapped {
+			state = gamepads.DeadZone.Apply(state)
+		}
+		gamepad.update(state, device.Raw, mapped)
+	}
+	gamepads.mutex.Unlock()
+
+	for _, e := range events {
+		_, _ = gamepads.Dispatch(e)
+	}
+	return errors.Join(errs...)
+}
+
+// poll reads a joystick slot from the backend
+func (gamepads *Gamepads) poll(id int) (Device, bool, error) {
+	if gamepads.Backend == nil {
+		return Device{}, false, nil
+	}
+	return gamepads.Backend.Poll(id)
+}
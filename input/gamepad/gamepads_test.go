@@ -0,0 +1,188 @@
+package gamepad
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gjh33/SurrealEngine/core/mainthread"
+)
+
+// hotplugRecorder records connections and disconnections, in order
+type hotplugRecorder struct {
+	events []string
+}
+
+func (recorder *hotplugRecorder) OnGamepadConnected(e GamepadConnectedEvent) {
+	recorder.events = append(recorder.events, "connected "+e.Name)
+}
+
+func (recorder *hotplugRecorder) OnGamepadDisconnected(e GamepadDisconnectedEvent) {
+	recorder.events = append(recorder.events, "disconnected "+e.Name)
+}
+
+func TestGamepadsHotplug(t *testing.T) {
+	virtual := new(Virtual)
+	gamepads := New(virtual)
+	recorder := &hotplugRecorder{}
+	if _, err := gamepads.Subscribe(recorder); err != nil {
+		t.Fatal(err)
+	}
+
+	id := virtual.Connect("pad")
+	if gamepads.Get(id) != nil {
+		t.Fatal("the gamepad showed up before Update")
+	}
+	gamepads.Update()
+	gamepad := gamepads.Get(id)
+	if gamepad == nil || !gamepad.IsConnected() || !gamepad.IsMapped() {
+		t.Fatalf("after Update got %+v, want a connected, mapped gamepad", gamepad)
+	}
+	if connected := gamepads.Connected(); len(connected) != 1 || connected[0] != gamepad {
+		t.Errorf("Connected is %v, want only the new gamepad", connected)
+	}
+
+	virtual.Press(id, ButtonA)
+	gamepads.Update()
+	if !gamepad.IsDown(ButtonA) || !gamepad.WasPressedThisFrame(ButtonA) {
+		t.Error("ButtonA was not pressed this frame")
+	}
+	gamepads.Update()
+	if !gamepad.IsDown(ButtonA) || gamepad.WasPressedThisFrame(ButtonA) {
+		t.Error("ButtonA was pressed again on the next frame")
+	}
+
+	virtual.Disconnect(id)
+	gamepads.Update()
+	if gamepads.Get(id) != nil || gamepad.IsConnected() {
+		t.Error("the gamepad is still connected after unplugging it")
+	}
+	if gamepad.IsDown(ButtonA) || !gamepad.WasReleasedThisFrame(ButtonA) {
+		t.Error("unplugging did not let go of ButtonA")
+	}
+	gamepads.Update()
+	if gamepad.WasReleasedThisFrame(ButtonA) {
+		t.Error("ButtonA was released again on the frame after unplugging")
+	}
+
+	want := []string{"connected pad", "disconnected pad"}
+	if !slices.Equal(recorder.events, want) {
+		t.Errorf("dispatched %v, want %v", recorder.events, want)
+	}
+}
+
+func TestGamepadsSwapInOneFrame(t *testing.T) {
+	virtual := new(Virtual)
+	gamepads := New(virtual)
+	recorder := &hotplugRecorder{}
+	if _, err := gamepads.Subscribe(recorder); err != nil {
+		t.Fatal(err)
+	}
+	id := virtual.Connect("pad")
+	gamepads.Update()
+	old := gamepads.Get(id)
+
+	virtual.Disconnect(id)
+	if virtual.ConnectJoystick("stick", "03000000000000000000000000000001", 2, 2, 0) != id {
+		t.Fatal("the joystick did not take the freed slot")
+	}
+	gamepads.Update()
+	if current := gamepads.Get(id); current == old || current.Name != "stick" || old.IsConnected() {
+		t.Errorf("slot %d holds %+v, want the joystick in place of the gamepad", id, current)
+	}
+	want := []string{"connected pad", "disconnected pad", "connected stick"}
+	if !slices.Equal(recorder.events, want) {
+		t.Errorf("dispatched %v, want %v", recorder.events, want)
+	}
+}
+
+func TestGamepadsNameFromMapping(t *testing.T) {
+	virtual := new(Virtual)
+	gamepads := New(virtual)
+	recorder := &hotplugRecorder{}
+	if _, err := gamepads.Subscribe(recorder); err != nil {
+		t.Fatal(err)
+	}
+	gamepads.Mappings.Add(MustParseMapping("03000000de280000ff11000001000000,Steam Virtual Gamepad,a:b0,"))
+
+	mapped := virtual.ConnectJoystick("Generic USB Joystick", "03000000de280000ff11000001000000", 2, 2, 0)
+	unmapped := virtual.ConnectJoystick("Generic USB Joystick", "03000000000000000000000000000001", 2, 2, 0)
+	gamepads.Update()
+	if name := gamepads.Get(mapped).Name; name != "Steam Virtual Gamepad" {
+		t.Errorf("mapped joystick is named %q, want the mapping's name", name)
+	}
+	if name := gamepads.Get(unmapped).Name; name != "Generic USB Joystick" {
+		t.Errorf("unmapped joystick is named %q, want the name it reports", name)
+	}
+	if gamepads.Get(unmapped).IsMapped() {
+		t.Error("a joystick without a mapping has a standard layout")
+	}
+	want := []string{"connected Steam Virtual Gamepad", "connected Generic USB Joystick"}
+	if !slices.Equal(recorder.events, want) {
+		t.Errorf("dispatched %v, want %v", recorder.events, want)
+	}
+}
+
+func TestGamepadsWithoutBackend(t *testing.T) {
+	gamepads := New(nil)
+	gamepads.Update()
+	if connected := gamepads.Connected(); len(connected) != 0 {
+		t.Errorf("Connected is %v without a backend", connected)
+	}
+	if gamepads.Get(-1) != nil || gamepads.Get(MaxJoysticks) != nil {
+		t.Error("Get returned a gamepad for an ID out of range")
+	}
+}
+
+// failingBackend is a Virtual that can't be read while broken
+type failingBackend struct {
+	Virtual
+	broken bool
+}
+
+var errUnreadable = errors.New("unreadable")
+
+func (backend *failingBackend) Poll(id int) (Device, bool, error) {
+	if backend.broken {
+		return Device{}, false, errUnreadable
+	}
+	return backend.Virtual.Poll(id)
+}
+
+func TestGamepadsPollError(t *testing.T) {
+	backend := new(failingBackend)
+	gamepads := New(backend)
+	recorder := &hotplugRecorder{}
+	if _, err := gamepads.Subscribe(recorder); err != nil {
+		t.Fatal(err)
+	}
+	id := backend.Connect("pad")
+	backend.Press(id, ButtonA)
+	if err := gamepads.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.broken = true
+	if err := gamepads.Update(); err == nil || !strings.Contains(err.Error(), errUnreadable.Error()) {
+		t.Errorf("Update returned %v, want the backend's error", err)
+	}
+	gamepad := gamepads.Get(id)
+	if gamepad == nil || !gamepad.IsConnected() {
+		t.Fatal("a gamepad that could not be read was disconnected")
+	}
+	if !gamepad.IsDown(ButtonA) || gamepad.WasPressedThisFrame(ButtonA) {
+		t.Error("a gamepad that could not be read did not keep its state for a new frame")
+	}
+	want := []string{"connected pad"}
+	if !slices.Equal(recorder.events, want) {
+		t.Errorf("dispatched %v, want %v", recorder.events, want)
+	}
+}
+
+func TestGLFWBackendWithoutMainThread(t *testing.T) {
+	// Tests don't run on the main goroutine, and nothing is bound outside of the application's run loop
+	if _, ok, err := (GLFWBackend{}).Poll(0); ok || !errors.As(err, new(mainthread.NotBoundError)) {
+		t.Errorf("Poll returned %v, %v, want a mainthread.NotBoundError", ok, err)
+	}
+}
@@ -0,0 +1,61 @@
+package gamepad
+
+import (
+	"github.com/gjh33/SurrealEngine/core/mainthread"
+	"github.com/vulkan-go/glfw/v3.3/glfw"
+)
+
+// GLFWBackend reads joysticks through GLFW. GLFW must be initialized, which the Vulkan context does when it creates its window.
+// GLFW knows the layout of most gamepads from its own copy of SDL_GameControllerDB, the rest need a mapping
+type GLFWBackend struct{}
+
+// Poll implements the Backend interface
+// GLFW may only be used from the main thread, so outside of the application's run loop this returns a mainthread.NotBoundError
+func (backend GLFWBackend) Poll(id int) (device Device, ok bool, err error) {
+	joystick := glfw.Joystick1 + glfw.Joystick(id)
+	if id < 0 || joystick > glfw.JoystickLast {
+		return Device{}, false, nil
+	}
+	err = mainthread.CallSync(func() error {
+		if ok = joystick.Present(); !ok {
+			return nil
+		}
+		device.Name = joystick.GetName()
+		device.GUID = joystick.GetGUID()
+		for _, axis := range joystick.GetAxes() {
+			device.Raw.Axes = append(device.Raw.Axes, float64(axis))
+		}
+		for _, button := range joystick.GetButtons() {
+			device.Raw.Buttons = append(device.Raw.Buttons, button == glfw.Press)
+		}
+		for _, hat := range joystick.GetHats() {
+			device.Raw.Hats = append(device.Raw.Hats, Hat(hat)) // GLFW uses the same direction flags
+		}
+		if joystick.IsGamepad() {
+			device.Name = joystick.GetGamepadName()
+			device.State, device.Standard = stateFromGLFW(joystick.GetGamepadState())
+		}
+		return nil
+	})
+	if err != nil {
+		return Device{}, false, err
+	}
+	return device, ok, nil
+}
+
+// stateFromGLFW converts a GLFW gamepad state, whose layout matches ours except for triggers going from -1 to 1
+func stateFromGLFW(gamepad *glfw.GamepadState) (state State, ok bool) {
+	if gamepad == nil {
+		return State{}, false
+	}
+	for button := range state.Buttons {
+		state.Buttons[button] = gamepad.Buttons[button] == glfw.Press
+	}
+	for axis := range state.Axes {
+		state.Axes[axis] = float64(gamepad.Axes[axis])
+		if Axis(axis).IsTrigger() {
+			state.Axes[axis] = (state.Axes[axis] + 1) / 2
+		}
+	}
+	return state, true
+}
@@ -0,0 +1,312 @@
+package gamepad
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Mapping maps the raw input of a joystick onto the standard gamepad layout.
+// Mappings are written in the SDL_GameControllerDB format, i.e.
+//
+//	03000000de280000ff11000001000000,Steam Virtual Gamepad,a:b0,b:b1,x:b2,y:b3,leftx:a0,lefty:a1,dpup:h0.1,lefttrigger:a2,platform:Linux,
+//
+// See https://github.com/gabomdq/SDL_GameControllerDB
+type Mapping struct {
+	GUID     string
+	Name     string
+	Platform string // Platform the mapping is for in SDL's naming, i.e. "Windows", "Mac OS X" or "Linux". Empty for any
+
+	bindings []binding
+}
+
+// binding maps one raw input onto a button or axis of the standard layout
+type binding struct {
+	button Button // Target when the target is a button, otherwise -1
+	axis   Axis   // Target when the target is an axis, otherwise -1
+	half   int    // 1 or -1 when the target is only the positive or negative half of the axis, 0 for all of it
+	source source
+}
+
+// source is a raw input of a joystick
+type source struct {
+	kind   byte // 'b' for buttons, 'h' for hats and 'a' for axes
+	index  int
+	hat    Hat // Direction of the hat that counts as pressed
+	half   int // 1 or -1 when only the positive or negative half of the axis is used, 0 for all of it
+	invert bool
+}
+
+// mappingButtons are the SDL names of the standard layout's buttons
+var mappingButtons = map[string]Button{
+	"a":             ButtonA,
+	"b":             ButtonB,
+	"x":             ButtonX,
+	"y":             ButtonY,
+	"leftshoulder":  ButtonLeftBumper,
+	"rightshoulder": ButtonRightBumper,
+	"back":          ButtonBack,
+	"start":         ButtonStart,
+	"guide":         ButtonGuide,
+	"leftstick":     ButtonLeftThumb,
+	"rightstick":    ButtonRightThumb,
+	"dpup":          ButtonDpadUp,
+	"dpright":       ButtonDpadRight,
+	"dpdown":        ButtonDpadDown,
+	"dpleft":        ButtonDpadLeft,
+}
+
+// mappingAxes are the SDL names of the standard layout's axes
+var mappingAxes = map[string]Axis{
+	"leftx":        AxisLeftX,
+	"lefty":        AxisLeftY,
+	"rightx":       AxisRightX,
+	"righty":       AxisRightY,
+	"lefttrigger":  AxisLeftTrigger,
+	"righttrigger": AxisRightTrigger,
+}
+
+// platforms are SDL's platform names by GOOS
+var platforms = map[string]string{
+	"windows": "Windows",
+	"darwin":  "Mac OS X",
+	"linux":   "Linux",
+	"ios":     "iOS",
+	"android": "Android",
+}
+
+// MappingError is the error returned when a mapping can not be parsed
+type MappingError struct {
+	Line   int    // Line of the mapping in the database, 0 when parsing a single mapping
+	Field  string // Field that is malformed, empty if the mapping as a whole is
+	Reason string
+}
+
+// Error implements the error interface
+func (err MappingError) Error() string {
+	message := "gamepad mapping"
+	if err.Line > 0 {
+		message += fmt.Sprintf(" on line %d", err.Line)
+	}
+	if err.Field != "" {
+		message += fmt.Sprintf(", field %q", err.Field)
+	}
+	return message + ": " + err.Reason
+}
+
+// ParseMapping parses a mapping in the SDL_GameControllerDB format.
+// Inputs the standard layout has no place for, like paddles and touchpads, are ignored so newer databases keep loading
+func ParseMapping(text string) (Mapping, error) {
+	fields := strings.Split(strings.TrimSpace(text), ",")
+	if len(fields) < 2 {
+		return Mapping{}, MappingError{Reason: "expected a GUID and a name"}
+	}
+	mapping := Mapping{GUID: strings.ToLower(fields[0]), Name: fields[1]}
+	if len(mapping.GUID) != 32 || strings.Trim(mapping.GUID, "0123456789abcdef") != "" {
+		return Mapping{}, MappingError{Field: fields[0], Reason: "GUID must be 32 hex digits"}
+	}
+	for _, field := range fields[2:] {
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			return Mapping{}, MappingError{Field: field, Reason: "expected key:value"}
+		}
+		if key == "platform" {
+			mapping.Platform = value
+			continue
+		}
+		target := binding{button: -1, axis: -1}
+		if len(key) > 0 && (key[0] == '+' || key[0] == '-') {
+			target.half = halfOf(key[0])
+			key = key[1:]
+		}
+		if button, ok := mappingButtons[key]; ok {
+			if target.half != 0 {
+				return Mapping{}, MappingError{Field: field, Reason: "buttons have no halves"}
+			}
+			target.button = button
+		} else if axis, ok := mappingAxes[key]; ok {
+			target.axis = axis
+		} else {
+			continue
+		}
+		var err error
+		if target.source, err = parseSource(value); err != nil {
+			return Mapping{}, MappingError{Field: field, Reason: err.Error()}
+		}
+		mapping.bindings = append(mapping.bindings, target)
+	}
+	return mapping, nil
+}
+
+// parseSource parses a raw input, i.e. b3 for a button, h0.4 for a hat direction or +a2~ for a half axis, inverted
+func parseSource(text string) (source, error) {
+	var input source
+	if len(text) > 0 && (text[0] == '+' || text[0] == '-') {
+		input.half = halfOf(text[0])
+		text = text[1:]
+	}
+	if strings.HasSuffix(text, "~") {
+		input.invert = true
+		text = text[:len(text)-1]
+	}
+	if len(text) < 2 {
+		return source{}, errors.New("expected an input like b0, a0 or h0.1")
+	}
+	input.kind = text[0]
+	if (input.half != 0 || input.invert) && input.kind != 'a' {
+		return source{}, errors.New("only axes have halves or can be inverted")
+	}
+	var err error
+	switch input.kind {
+	case 'b', 'a':
+		input.index, err = strconv.Atoi(text[1:])
+	case 'h':
+		index, mask, ok := strings.Cut(text[1:], ".")
+		if !ok {
+			return source{}, errors.New("expected a hat like h0.1")
+		}
+		if input.index, err = strconv.Atoi(index); err == nil {
+			var hat int
+			hat, err = strconv.Atoi(mask)
+			input.hat = Hat(hat)
+		}
+	default:
+		return source{}, fmt.Errorf("unknown input type %q", input.kind)
+	}
+	if err != nil || input.index < 0 || input.hat < 0 {
+		return source{}, fmt.Errorf("malformed input %q", text)
+	}
+	return input, nil
+}
+
+// halfOf returns the half of an axis a + or - prefix stands for
+func halfOf(sign byte) int {
+	if sign == '-' {
+		return -1
+	}
+	return 1
+}
+
+// MustParseMapping is ParseMapping, but panics if the mapping is malformed. For mappings built into the program
+func MustParseMapping(text string) Mapping {
+	mapping, err := ParseMapping(text)
+	if err != nil {
+		panic(err)
+	}
+	return mapping
+}
+
+// IsForPlatform returns if the mapping applies to the platform the program runs on
+func (mapping Mapping) IsForPlatform() bool {
+	return mapping.Platform == "" || mapping.Platform == platforms[runtime.GOOS]
+}
+
+// Apply maps a joystick's raw input onto the standard layout. Inputs the device does not have read as let go of
+func (mapping Mapping) Apply(raw Raw) State {
+	var state State
+	for _, binding := range mapping.bindings {
+		value := binding.source.read(raw)
+		switch {
+		case binding.button >= 0:
+			state.Buttons[binding.button] = value > 0
+		case binding.axis.IsTrigger():
+			state.Axes[binding.axis] = (value + 1) / 2
+		case binding.half != 0:
+			state.Axes[binding.axis] += float64(binding.half) * (value + 1) / 2
+		default:
+			state.Axes[binding.axis] = value
+		}
+	}
+	for axis := range state.Axes {
+		state.Axes[axis] = math.Max(-1, math.Min(state.Axes[axis], 1))
+	}
+	return state
+}
+
+// read returns the value of the input from -1 to 1. Buttons and hats read 1 when pressed, and half axes read -1 at the center
+func (input source) read(raw Raw) float64 {
+	var value float64
+	switch input.kind {
+	case 'b':
+		value = -1
+		if input.index < len(raw.Buttons) && raw.Buttons[input.index] {
+			value = 1
+		}
+	case 'h':
+		value = -1
+		if input.index < len(raw.Hats) && raw.Hats[input.index].Has(input.hat) {
+			value = 1
+		}
+	case 'a':
+		if input.index < len(raw.Axes) {
+			value = raw.Axes[input.index]
+		}
+		if input.half != 0 {
+			value = math.Max(0, value*float64(input.half))*2 - 1
+		}
+	}
+	if input.invert {
+		value = -value
+	}
+	return value
+}
+
+// Mappings is a database of mappings by GUID. The zero value is an empty database ready to use.
+// Safe to use from any goroutine
+type Mappings struct {
+	mutex  sync.RWMutex
+	byGUID map[string]Mapping
+}
+
+// Add adds a mapping, replacing the one for the same GUID, if any
+func (mappings *Mappings) Add(mapping Mapping) {
+	mappings.mutex.Lock()
+	defer mappings.mutex.Unlock()
+	if mappings.byGUID == nil {
+		mappings.byGUID = make(map[string]Mapping)
+	}
+	mappings.byGUID[mapping.GUID] = mapping
+}
+
+// AddDatabase adds every mapping for this platform from a database in the SDL_GameControllerDB format, i.e. the contents of gamecontrollerdb.txt.
+// Blank lines and # comments are skipped. Malformed lines are skipped too, and returned as MappingErrors joined together
+func (mappings *Mappings) AddDatabase(database string) error {
+	var errs []error
+	scanner := bufio.NewScanner(strings.NewReader(database))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		mapping, err := ParseMapping(text)
+		if err != nil {
+			var mappingErr MappingError
+			if errors.As(err, &mappingErr) {
+				mappingErr.Line = line
+				err = mappingErr
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if mapping.IsForPlatform() {
+			mappings.Add(mapping)
+		}
+	}
+	return errors.Join(append(errs, scanner.Err())...)
+}
+
+// Lookup returns the mapping for a GUID
+func (mappings *Mappings) Lookup(guid string) (Mapping, bool) {
+	mappings.mutex.RLock()
+	defer mappings.mutex.RUnlock()
+	mapping, ok := mappings.byGUID[strings.ToLower(guid)]
+	return mapping, ok
+}
@@ -0,0 +1,112 @@
+package gamepad
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+// testMapping binds a bit of everything the SDL format has: buttons, hats, inverted axes, half axes as sources and as targets
+const testMapping = "03000000de280000ff11000001000000,Test Pad,a:b0,b:b1,dpup:h0.1,dpright:h0.2,leftx:a0,lefty:a1~,lefttrigger:+a2,righttrigger:-a3,-rightx:b2,+rightx:b3,paddle1:b4,"
+
+func TestMappingThroughGamepads(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		input func(virtual *Virtual, id int)
+		check func(state State) bool
+	}{
+		{"buttons", func(virtual *Virtual, id int) { virtual.SetRawButton(id, 1, true) },
+			func(state State) bool { return state.Buttons[ButtonB] && !state.Buttons[ButtonA] }},
+		{"hat diagonal", func(virtual *Virtual, id int) { virtual.SetRawHat(id, 0, HatUp|HatRight) },
+			func(state State) bool { return state.Buttons[ButtonDpadUp] && state.Buttons[ButtonDpadRight] }},
+		{"hat one way", func(virtual *Virtual, id int) { virtual.SetRawHat(id, 0, HatRight) },
+			func(state State) bool { return !state.Buttons[ButtonDpadUp] && state.Buttons[ButtonDpadRight] }},
+		{"axis", func(virtual *Virtual, id int) { virtual.SetRawAxis(id, 0, -0.5) },
+			func(state State) bool { return state.Axes[AxisLeftX] == -0.5 }},
+		{"inverted axis", func(virtual *Virtual, id int) { virtual.SetRawAxis(id, 1, 0.5) },
+			func(state State) bool { return state.Axes[AxisLeftY] == -0.5 }},
+		{"triggers at rest", func(virtual *Virtual, id int) {},
+			func(state State) bool { return state.Axes[AxisLeftTrigger] == 0 && state.Axes[AxisRightTrigger] == 0 }},
+		{"half axis trigger", func(virtual *Virtual, id int) { virtual.SetRawAxis(id, 2, 0.5) },
+			func(state State) bool { return state.Axes[AxisLeftTrigger] == 0.5 }},
+		{"half axis trigger, other half", func(virtual *Virtual, id int) { virtual.SetRawAxis(id, 2, -1) },
+			func(state State) bool { return state.Axes[AxisLeftTrigger] == 0 }},
+		{"negative half axis trigger", func(virtual *Virtual, id int) { virtual.SetRawAxis(id, 3, -1) },
+			func(state State) bool { return state.Axes[AxisRightTrigger] == 1 }},
+		{"half axis targets", func(virtual *Virtual, id int) { virtual.SetRawButton(id, 2, true) },
+			func(state State) bool { return state.Axes[AxisRightX] == -1 }},
+		{"half axis targets, other half", func(virtual *Virtual, id int) { virtual.SetRawButton(id, 3, true) },
+			func(state State) bool { return state.Axes[AxisRightX] == 1 }},
+		{"half axis targets, both", func(virtual *Virtual, id int) {
+			virtual.SetRawButton(id, 2, true)
+			virtual.SetRawButton(id, 3, true)
+		}, func(state State) bool { return state.Axes[AxisRightX] == 0 }},
+	} {
+		virtual := new(Virtual)
+		gamepads := New(virtual)
+		gamepads.DeadZone = DeadZone{}
+		gamepads.Mappings.Add(MustParseMapping(testMapping))
+		// GUIDs are matched whatever their case
+		id := virtual.ConnectJoystick("joystick", "03000000DE280000FF11000001000000", 4, 5, 1)
+		test.input(virtual, id)
+		gamepads.Update()
+		gamepad := gamepads.Get(id)
+		if !gamepad.IsMapped() {
+			t.Fatalf("%s: the joystick is not mapped", test.name)
+		}
+		if state := gamepad.State(); !test.check(state) {
+			t.Errorf("%s: mapped to %+v", test.name, state)
+		}
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping(testMapping + "platform:Linux,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.GUID != "03000000de280000ff11000001000000" || mapping.Name != "Test Pad" || mapping.Platform != "Linux" {
+		t.Errorf("parsed %+v", mapping)
+	}
+
+	for _, text := range []string{
+		"03000000de280000ff11000001000000",
+		"0300,Short GUID,a:b0,",
+		"03000000de280000ff1100000100000g,Not hex,a:b0,",
+		"03000000de280000ff11000001000000,No colon,a,",
+		"03000000de280000ff11000001000000,Half button,+a:b0,",
+		"03000000de280000ff11000001000000,Inverted button,a:b0~,",
+		"03000000de280000ff11000001000000,Unknown input,a:q0,",
+		"03000000de280000ff11000001000000,Hat without direction,dpup:h0,",
+		"03000000de280000ff11000001000000,Negative index,a:b-1,",
+	} {
+		var mappingErr MappingError
+		if _, err := ParseMapping(text); !errors.As(err, &mappingErr) {
+			t.Errorf("ParseMapping(%q) returned %v, want a MappingError", text, err)
+		}
+	}
+}
+
+func TestMappingsAddDatabase(t *testing.T) {
+	database := "# Comments and blank lines are skipped\n" +
+		"\n" +
+		"03000000000000000000000000000001,Here,a:b0,platform:" + platforms[runtime.GOOS] + ",\n" +
+		"03000000000000000000000000000002,Elsewhere,a:b0,platform:Plan 9,\n" +
+		"03000000000000000000000000000003,Anywhere,a:b0,\n" +
+		"malformed\n"
+	var mappings Mappings
+	err := mappings.AddDatabase(database)
+	var mappingErr MappingError
+	if !errors.As(err, &mappingErr) || mappingErr.Line != 6 {
+		t.Errorf("AddDatabase returned %v, want a MappingError on line 6", err)
+	}
+	for guid, want := range map[string]bool{
+		"03000000000000000000000000000001": true,
+		"03000000000000000000000000000002": false,
+		"03000000000000000000000000000003": true,
+	} {
+		if _, ok := mappings.Lookup(guid); ok != want {
+			t.Errorf("Lookup(%q) found a mapping: %v, want %v", guid, ok, want)
+		}
+	}
+}
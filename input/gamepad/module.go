@@ -0,0 +1,52 @@
+package gamepad
+
+import (
+	"math"
+
+	"github.com/gjh33/SurrealEngine/core/app"
+	"github.com/gjh33/SurrealEngine/core/event"
+)
+
+// ModuleName is the name the gamepad module is registered under
+const ModuleName = "gamepad"
+
+// Module polls Gamepads every frame, before any other ApplicationUpdateEvent listener runs.
+// While running, its Gamepads are also provided as a service to the application
+type Module struct {
+	Gamepads *Gamepads // Defaults to reading GLFW joysticks, or to no devices when headless
+
+	subscription *event.Subscription
+}
+
+// Name implements the app.Module interface
+func (module *Module) Name() string {
+	return ModuleName
+}
+
+// Dependencies implements the app.Module interface
+// GLFW is initialized along with the window, so joysticks can only be read after it
+func (module *Module) Dependencies() []string {
+	return []string{app.GraphicsModuleName}
+}
+
+// Init implements the app.Module interface
+func (module *Module) Init(application *app.Application) error {
+	if module.Gamepads == nil {
+		var backend Backend = GLFWBackend{}
+		if application.Options.Headless {
+			backend = nil
+		}
+		module.Gamepads = New(backend)
+	}
+	app.Provide(application, module.Gamepads)
+	module.subscription = event.SubscribeErrPriority(application.Bus(), math.MaxInt, func(e app.ApplicationUpdateEvent) error {
+		return module.Gamepads.Update()
+	})
+	return nil
+}
+
+// Shutdown implements the app.Module interface
+func (module *Module) Shutdown(application *app.Application) error {
+	module.subscription.Cancel()
+	return nil
+}
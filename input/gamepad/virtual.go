@@ -0,0 +1,130 @@
+package gamepad
+
+import "sync"
+
+// VirtualGUID is the GUID of gamepads connected with Virtual.Connect
+const VirtualGUID = "7669727475616c000000000000000000"
+
+// Virtual is a Backend of simulated devices, i.e. for tests or bots driving a headless application.
+// Like a real device, changes show up in Gamepads on its next Update. Safe to use from any goroutine
+type Virtual struct {
+	mutex   sync.Mutex
+	devices [MaxJoysticks]*Device
+}
+
+// Poll implements the Backend interface
+func (virtual *Virtual) Poll(id int) (Device, bool, error) {
+	virtual.mutex.Lock()
+	defer virtual.mutex.Unlock()
+	if id < 0 || id >= MaxJoysticks || virtual.devices[id] == nil {
+		return Device{}, false, nil
+	}
+	device := *virtual.devices[id]
+	device.Raw = Raw{
+		Axes:    append([]float64(nil), device.Raw.Axes...),
+		Buttons: append([]bool(nil), device.Raw.Buttons...),
+		Hats:    append([]Hat(nil), device.Raw.Hats...),
+	}
+	return device, true, nil
+}
+
+// Connect plugs in a gamepad with the standard layout, returning its ID, or -1 if every slot is taken
+func (virtual *Virtual) Connect(name string) int {
+	return virtual.plug(&Device{Name: name, GUID: VirtualGUID, Standard: true})
+}
+
+// ConnectJoystick plugs in a joystick without a standard layout, returning its ID, or -1 if every slot is taken.
+// Give it a GUID with a mapping to test the mapping
+func (virtual *Virtual) ConnectJoystick(name string, guid string, axes int, buttons int, hats int) int {
+	return virtual.plug(&Device{Name: name, GUID: guid, Raw: Raw{
+		Axes:    make([]float64, axes),
+		Buttons: make([]bool, buttons),
+		Hats:    make([]Hat, hats),
+	}})
+}
+
+// Disconnect unplugs a device
+func (virtual *Virtual) Disconnect(id int) {
+	virtual.mutex.Lock()
+	defer virtual.mutex.Unlock()
+	if id >= 0 && id < MaxJoysticks {
+		virtual.devices[id] = nil
+	}
+}
+
+// Press holds down a button of a standard gamepad
+func (virtual *Virtual) Press(id int, button Button) {
+	virtual.setButton(id, button, true)
+}
+
+// Release lets go of a button of a standard gamepad
+func (virtual *Virtual) Release(id int, button Button) {
+	virtual.setButton(id, button, false)
+}
+
+// SetAxis moves a stick or trigger of a standard gamepad. Dead zones apply to it like to a real one
+func (virtual *Virtual) SetAxis(id int, axis Axis, value float64) {
+	virtual.change(id, func(device *Device) {
+		if axis >= 0 && axis < AxisCount {
+			device.State.Axes[axis] = value
+		}
+	})
+}
+
+// SetRawAxis moves an axis of a joystick, from -1 to 1
+func (virtual *Virtual) SetRawAxis(id int, index int, value float64) {
+	virtual.change(id, func(device *Device) {
+		if index >= 0 && index < len(device.Raw.Axes) {
+			device.Raw.Axes[index] = value
+		}
+	})
+}
+
+// SetRawButton holds down or lets go of a button of a joystick
+func (virtual *Virtual) SetRawButton(id int, index int, down bool) {
+	virtual.change(id, func(device *Device) {
+		if index >= 0 && index < len(device.Raw.Buttons) {
+			device.Raw.Buttons[index] = down
+		}
+	})
+}
+
+// SetRawHat pushes a hat of a joystick in a direction
+func (virtual *Virtual) SetRawHat(id int, index int, hat Hat) {
+	virtual.change(id, func(device *Device) {
+		if index >= 0 && index < len(device.Raw.Hats) {
+			device.Raw.Hats[index] = hat
+		}
+	})
+}
+
+// plug connects a device to the first free slot
+func (virtual *Virtual) plug(device *Device) int {
+	virtual.mutex.Lock()
+	defer virtual.mutex.Unlock()
+	for id := range virtual.devices {
+		if virtual.devices[id] == nil {
+			virtual.devices[id] = device
+			return id
+		}
+	}
+	return -1
+}
+
+// setButton holds down or lets go of a button of a standard gamepad
+func (virtual *Virtual) setButton(id int, button Button, down bool) {
+	virtual.change(id, func(device *Device) {
+		if button >= 0 && button < ButtonCount {
+			device.State.Buttons[button] = down
+		}
+	})
+}
+
+// change edits a connected device
+func (virtual *Virtual) change(id int, fn func(device *Device)) {
+	virtual.mutex.Lock()
+	defer virtual.mutex.Unlock()
+	if id >= 0 && id < MaxJoysticks && virtual.devices[id] != nil {
+		fn(virtual.devices[id])
+	}
+}